@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -18,6 +21,20 @@ type Config struct {
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
 
+	// RequestTimeout bounds each request; paths starting with one of the
+	// RequestTimeoutExempt prefixes are not bounded
+	RequestTimeout       time.Duration
+	RequestTimeoutExempt []string
+
+	// BodyLimit is the largest request body accepted, in bytes; larger ones
+	// get a 413. ServerConcurrency caps simultaneous connections.
+	BodyLimit         int
+	ServerConcurrency int
+	// TrustedProxyHeader names the header (e.g. X-Forwarded-For) holding the
+	// client IP behind a reverse proxy. Leave it empty when clients connect
+	// directly, since they could otherwise spoof their IP.
+	TrustedProxyHeader string
+
 	// Database
 	DBDriver   string
 	DBHost     string
@@ -26,6 +43,14 @@ type Config struct {
 	DBPassword string
 	DBName     string
 	DBSSLMode  string
+	DBParams   string
+
+	DBConnectMaxRetries int
+	DBConnectBackoff    time.Duration
+
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
 
 	// JWT
 	JWTSecret        string
@@ -33,14 +58,70 @@ type Config struct {
 	JWTRefreshExpiry time.Duration
 
 	// CORS
-	CORSAllowedOrigins string
-	CORSAllowedMethods string
-	CORSAllowedHeaders string
+	// CORSAllowedOrigins holds exact origins and wildcard subdomain
+	// patterns such as https://*.example.com
+	CORSAllowedOrigins   []string
+	CORSAllowedMethods   string
+	CORSAllowedHeaders   string
+	CORSAllowCredentials bool
+
+	// Logging; the log file is rotated once it reaches LogMaxSizeMB, and
+	// rotated files beyond LogMaxBackups or older than LogMaxAgeDays are
+	// removed (0 keeps them all)
+	LogLevel      string
+	LogMaxSizeMB  int
+	LogMaxBackups int
+	LogMaxAgeDays int
+	LogCompress   bool
 
-	// Logging
-	LogLevel string
+	// Rate limiting (per client IP, on /api)
+	RateLimitMax    int
+	RateLimitWindow time.Duration
+
+	// IdempotencyTTL is how long the response to a request with an
+	// Idempotency-Key is replayed for retries
+	IdempotencyTTL time.Duration
+
+	// AdminToken is the static token the X-Admin-Token header must carry on
+	// admin endpoints (replace imports, preset apply, orphans, validate);
+	// leaving it empty disables those endpoints
+	AdminToken string
+
+	// Menu
+	MenuMaxDepth int
+	MenuTitleMax int
+	MenuPathMax  int
+	MenuIconMax  int
+	// MenuCacheEnabled caches built menu trees in memory; writes through the
+	// API invalidate them, and MenuCacheTTL bounds how long a tree written
+	// to the database by other means can stay stale
+	MenuCacheEnabled bool
+	MenuCacheTTL     time.Duration
 }
 
+// Menu column sizes in the database; the configurable length limits must
+// not exceed them
+const (
+	MenuTitleColumnSize = 255
+	MenuPathColumnSize  = 255
+	MenuIconColumnSize  = 100
+)
+
+// Fiber's own body size and concurrency defaults
+const (
+	DefaultBodyLimit         = 4 * 1024 * 1024
+	DefaultServerConcurrency = 256 * 1024
+)
+
+// Rate limit used when none is configured
+const (
+	DefaultRateLimitMax    = 100
+	DefaultRateLimitWindow = time.Minute
+)
+
+// DefaultIdempotencyTTL is used when no idempotency TTL is configured
+const DefaultIdempotencyTTL = 24 * time.Hour
+
 var AppConfig *Config
 
 func LoadConfig() (*Config, error) {
@@ -58,6 +139,13 @@ func LoadConfig() (*Config, error) {
 		WriteTimeout: parseDuration(getEnv("WRITE_TIMEOUT", "10s")),
 		IdleTimeout:  parseDuration(getEnv("IDLE_TIMEOUT", "60s")),
 
+		RequestTimeout:       parseDuration(getEnv("REQUEST_TIMEOUT", "30s")),
+		RequestTimeoutExempt: splitList(getEnv("REQUEST_TIMEOUT_EXEMPT", "")),
+
+		BodyLimit:          getEnvAsInt("BODY_LIMIT", DefaultBodyLimit),
+		ServerConcurrency:  getEnvAsInt("SERVER_CONCURRENCY", DefaultServerConcurrency),
+		TrustedProxyHeader: getEnv("TRUSTED_PROXY_HEADER", ""),
+
 		// Database
 		DBDriver:   getEnv("DB_DRIVER", "postgres"),
 		DBHost:     getEnv("DB_HOST", "localhost"),
@@ -66,6 +154,16 @@ func LoadConfig() (*Config, error) {
 		DBPassword: getEnv("DB_PASSWORD", "postgres"),
 		DBName:     getEnv("DB_NAME", "stk_test"),
 		DBSSLMode:  getEnv("DB_SSL_MODE", "disable"),
+		// multiStatements lets the mysql driver run migration and seed files,
+		// which hold several statements each
+		DBParams: getEnv("DB_PARAMS", "charset=utf8mb4&parseTime=True&loc=UTC&multiStatements=true"),
+
+		DBConnectMaxRetries: getEnvAsInt("DB_CONNECT_MAX_RETRIES", 5),
+		DBConnectBackoff:    parseDuration(getEnv("DB_CONNECT_BACKOFF", "1s")),
+
+		DBMaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+		DBConnMaxLifetime: parseDuration(getEnv("DB_CONN_MAX_LIFETIME", "5m")),
 
 		// JWT
 		JWTSecret:        getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production"),
@@ -73,12 +171,34 @@ func LoadConfig() (*Config, error) {
 		JWTRefreshExpiry: parseDuration(getEnv("JWT_REFRESH_EXPIRY", "168h")),
 
 		// CORS
-		CORSAllowedOrigins: getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000"),
-		CORSAllowedMethods: getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
-		CORSAllowedHeaders: getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization"),
+		CORSAllowedOrigins:   splitList(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000")),
+		CORSAllowedMethods:   getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+		CORSAllowedHeaders:   getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization,Idempotency-Key,X-Admin-Token"),
+		CORSAllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", false),
 
 		// Logging
-		LogLevel: getEnv("LOG_LEVEL", "info"),
+		LogLevel:      getEnv("LOG_LEVEL", "info"),
+		LogMaxSizeMB:  getEnvAsInt("LOG_MAX_SIZE_MB", 100),
+		LogMaxBackups: getEnvAsInt("LOG_MAX_BACKUPS", 5),
+		LogMaxAgeDays: getEnvAsInt("LOG_MAX_AGE_DAYS", 30),
+		LogCompress:   getEnvAsBool("LOG_COMPRESS", false),
+
+		// Rate limiting
+		RateLimitMax:    getEnvAsInt("RATE_LIMIT_MAX", DefaultRateLimitMax),
+		RateLimitWindow: parseDuration(getEnv("RATE_LIMIT_WINDOW", DefaultRateLimitWindow.String())),
+
+		IdempotencyTTL: parseDuration(getEnv("IDEMPOTENCY_TTL", DefaultIdempotencyTTL.String())),
+
+		AdminToken: getEnv("ADMIN_TOKEN", ""),
+
+		// Menu
+		MenuMaxDepth: getEnvAsInt("MENU_MAX_DEPTH", 5),
+		MenuTitleMax: getEnvAsInt("MENU_TITLE_MAX", MenuTitleColumnSize),
+		MenuPathMax:  getEnvAsInt("MENU_PATH_MAX", MenuPathColumnSize),
+		MenuIconMax:  getEnvAsInt("MENU_ICON_MAX", MenuIconColumnSize),
+
+		MenuCacheEnabled: getEnvAsBool("MENU_CACHE_ENABLED", true),
+		MenuCacheTTL:     parseDuration(getEnv("MENU_CACHE_TTL", "5m")),
 	}
 
 	if err := config.Validate(); err != nil {
@@ -90,8 +210,90 @@ func LoadConfig() (*Config, error) {
 }
 
 func (c *Config) Validate() error {
-	if c.DBDriver != "postgres" && c.DBDriver != "sqlite" {
-		return fmt.Errorf("DB_DRIVER must be either 'postgres' or 'sqlite'")
+	switch c.DBDriver {
+	case "postgres", "mysql", "sqlite":
+	default:
+		return fmt.Errorf("DB_DRIVER must be one of 'postgres', 'mysql' or 'sqlite'")
+	}
+
+	if c.DBConnectMaxRetries < 0 {
+		return fmt.Errorf("DB_CONNECT_MAX_RETRIES must not be negative")
+	}
+
+	if c.DBConnectBackoff <= 0 {
+		return fmt.Errorf("DB_CONNECT_BACKOFF must be positive")
+	}
+
+	if c.DBMaxOpenConns < 1 {
+		return fmt.Errorf("DB_MAX_OPEN_CONNS must be at least 1")
+	}
+
+	if c.DBMaxIdleConns < 0 || c.DBMaxIdleConns > c.DBMaxOpenConns {
+		return fmt.Errorf("DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	}
+
+	if len(c.CORSAllowedOrigins) == 0 {
+		return fmt.Errorf("CORS_ALLOWED_ORIGINS must list at least one origin")
+	}
+
+	if c.CORSAllowCredentials && slices.Contains(c.CORSAllowedOrigins, "*") {
+		return fmt.Errorf("CORS_ALLOWED_ORIGINS cannot be '*' when CORS_ALLOW_CREDENTIALS is true")
+	}
+
+	if c.RequestTimeout < 0 {
+		return fmt.Errorf("REQUEST_TIMEOUT must not be negative")
+	}
+
+	if c.BodyLimit < 1 {
+		return fmt.Errorf("BODY_LIMIT must be at least 1 byte")
+	}
+
+	if c.ServerConcurrency < 1 {
+		return fmt.Errorf("SERVER_CONCURRENCY must be at least 1")
+	}
+
+	if strings.ContainsAny(c.TrustedProxyHeader, " \t:") {
+		return fmt.Errorf("TRUSTED_PROXY_HEADER must be a bare header name such as X-Forwarded-For")
+	}
+
+	if c.LogMaxSizeMB < 1 {
+		return fmt.Errorf("LOG_MAX_SIZE_MB must be at least 1")
+	}
+
+	if c.LogMaxBackups < 0 || c.LogMaxAgeDays < 0 {
+		return fmt.Errorf("LOG_MAX_BACKUPS and LOG_MAX_AGE_DAYS must not be negative")
+	}
+
+	if c.RateLimitMax < 1 {
+		return fmt.Errorf("RATE_LIMIT_MAX must be at least 1")
+	}
+
+	if c.RateLimitWindow <= 0 {
+		return fmt.Errorf("RATE_LIMIT_WINDOW must be positive")
+	}
+
+	if c.IdempotencyTTL <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_TTL must be positive")
+	}
+
+	if c.MenuMaxDepth < 1 {
+		return fmt.Errorf("MENU_MAX_DEPTH must be at least 1")
+	}
+
+	if c.MenuTitleMax < 1 || c.MenuTitleMax > MenuTitleColumnSize {
+		return fmt.Errorf("MENU_TITLE_MAX must be between 1 and %d", MenuTitleColumnSize)
+	}
+
+	if c.MenuPathMax < 1 || c.MenuPathMax > MenuPathColumnSize {
+		return fmt.Errorf("MENU_PATH_MAX must be between 1 and %d", MenuPathColumnSize)
+	}
+
+	if c.MenuIconMax < 1 || c.MenuIconMax > MenuIconColumnSize {
+		return fmt.Errorf("MENU_ICON_MAX must be between 1 and %d", MenuIconColumnSize)
+	}
+
+	if c.MenuCacheEnabled && c.MenuCacheTTL <= 0 {
+		return fmt.Errorf("MENU_CACHE_TTL must be positive when MENU_CACHE_ENABLED is true")
 	}
 
 	// Validate JWT Secret in production
@@ -102,6 +304,9 @@ func (c *Config) Validate() error {
 		if len(c.JWTSecret) < 32 {
 			return fmt.Errorf("JWT_SECRET must be at least 32 characters in production")
 		}
+		if c.AdminToken != "" && len(c.AdminToken) < 32 {
+			return fmt.Errorf("ADMIN_TOKEN must be at least 32 characters in production")
+		}
 	}
 
 	return nil
@@ -122,6 +327,43 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvAsInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: Invalid integer '%s' for %s, using default %d", value, key, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+func getEnvAsBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: Invalid boolean '%s' for %s, using default %t", value, key, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// splitList splits a comma-separated value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func parseDuration(s string) time.Duration {
 	duration, err := time.ParseDuration(s)
 	if err != nil {
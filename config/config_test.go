@@ -0,0 +1,76 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/config"
+)
+
+// validConfig returns a config that passes Validate, for tests to alter
+func validConfig() *config.Config {
+	return &config.Config{
+		DBDriver:           "postgres",
+		DBConnectBackoff:   time.Second,
+		DBMaxOpenConns:     1,
+		CORSAllowedOrigins: []string{"http://localhost:3000"},
+		BodyLimit:          config.DefaultBodyLimit,
+		ServerConcurrency:  config.DefaultServerConcurrency,
+		LogMaxSizeMB:       1,
+		RateLimitMax:       config.DefaultRateLimitMax,
+		RateLimitWindow:    config.DefaultRateLimitWindow,
+		IdempotencyTTL:     config.DefaultIdempotencyTTL,
+		MenuMaxDepth:       1,
+		MenuTitleMax:       config.MenuTitleColumnSize,
+		MenuPathMax:        config.MenuPathColumnSize,
+		MenuIconMax:        config.MenuIconColumnSize,
+	}
+}
+
+func TestValidate_CORSWildcardWithCredentials(t *testing.T) {
+	cfg := validConfig()
+	cfg.CORSAllowedOrigins = []string{"*"}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected '*' without credentials to be accepted, got %v", err)
+	}
+
+	cfg.CORSAllowCredentials = true
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected '*' with credentials to be rejected")
+	}
+
+	cfg.CORSAllowedOrigins = []string{"https://*.example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected a subdomain pattern with credentials to be accepted, got %v", err)
+	}
+}
+
+func TestValidate_ServerLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *config.Config)
+		valid  bool
+	}{
+		{name: "defaults", modify: func(cfg *config.Config) {}, valid: true},
+		{name: "proxy header", modify: func(cfg *config.Config) { cfg.TrustedProxyHeader = "X-Forwarded-For" }, valid: true},
+		{name: "zero body limit", modify: func(cfg *config.Config) { cfg.BodyLimit = 0 }},
+		{name: "zero concurrency", modify: func(cfg *config.Config) { cfg.ServerConcurrency = 0 }},
+		{name: "proxy header with value", modify: func(cfg *config.Config) { cfg.TrustedProxyHeader = "X-Real-IP: 1.2.3.4" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected config to be valid, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected config to be rejected")
+			}
+		})
+	}
+}
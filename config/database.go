@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -23,6 +24,19 @@ func (c *Config) GetDatabaseURL() string {
 			c.DBName,
 			c.DBSSLMode,
 		)
+	case "mysql":
+		dsn := fmt.Sprintf(
+			"%s:%s@tcp(%s:%s)/%s",
+			c.DBUser,
+			c.DBPassword,
+			c.DBHost,
+			c.DBPort,
+			c.DBName,
+		)
+		if c.DBParams != "" {
+			dsn += "?" + c.DBParams
+		}
+		return dsn
 	case "sqlite":
 		return c.DBName + ".db"
 	default:
@@ -36,6 +50,8 @@ func (c *Config) GetDialector() gorm.Dialector {
 	switch c.DBDriver {
 	case "postgres":
 		return postgres.Open(c.GetDatabaseURL())
+	case "mysql":
+		return mysql.Open(c.GetDatabaseURL())
 	case "sqlite":
 		return sqlite.Open(c.GetDatabaseURL())
 	default:
@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/config"
+)
+
+func TestGetDatabaseURL_MySQL(t *testing.T) {
+	cfg := &config.Config{
+		DBDriver:   "mysql",
+		DBHost:     "db.internal",
+		DBPort:     "3306",
+		DBUser:     "app",
+		DBPassword: "secret",
+		DBName:     "stk_test",
+		DBParams:   "charset=utf8mb4&parseTime=True&loc=UTC",
+	}
+
+	expected := "app:secret@tcp(db.internal:3306)/stk_test?charset=utf8mb4&parseTime=True&loc=UTC"
+	if got := cfg.GetDatabaseURL(); got != expected {
+		t.Errorf("Expected DSN %q, got %q", expected, got)
+	}
+
+	if name := cfg.GetDialector().Name(); name != "mysql" {
+		t.Errorf("Expected mysql dialector, got %q", name)
+	}
+}
+
+func TestGetDatabaseURL_MySQLWithoutParams(t *testing.T) {
+	cfg := &config.Config{
+		DBDriver:   "mysql",
+		DBHost:     "localhost",
+		DBPort:     "3306",
+		DBUser:     "root",
+		DBPassword: "root",
+		DBName:     "stk_test",
+	}
+
+	expected := "root:root@tcp(localhost:3306)/stk_test"
+	if got := cfg.GetDatabaseURL(); got != expected {
+		t.Errorf("Expected DSN %q, got %q", expected, got)
+	}
+}
+
+func TestValidate_DBDriver(t *testing.T) {
+	tests := []struct {
+		driver string
+		valid  bool
+	}{
+		{driver: "postgres", valid: true},
+		{driver: "mysql", valid: true},
+		{driver: "sqlite", valid: true},
+		{driver: "oracle", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.DBDriver = tt.driver
+
+			err := cfg.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected driver %q to be accepted, got %v", tt.driver, err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("Expected driver %q to be rejected", tt.driver)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package config
+
+// Build information, set at link time with
+//
+//	go build -ldflags "-X github.com/andhikadk/stk-test-be/config.Version=v1.2.3 \
+//		-X github.com/andhikadk/stk-test-be/config.Commit=$(git rev-parse --short HEAD) \
+//		-X github.com/andhikadk/stk-test-be/config.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Each is "dev" in builds that do not set it.
+var (
+	Version   = "dev"
+	Commit    = "dev"
+	BuildTime = "dev"
+)
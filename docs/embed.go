@@ -0,0 +1,9 @@
+package docs
+
+import _ "embed"
+
+// SwaggerJSON is the swagger.json generated next to this file, embedded so
+// the served document always matches the build
+//
+//go:embed swagger.json
+var SwaggerJSON []byte
@@ -1,8 +1,10 @@
 package database
 
 import (
-	"embed"
+	"fmt"
+	"io/fs"
 	"log"
+	"time"
 
 	"github.com/andhikadk/stk-test-be/config"
 	"github.com/andhikadk/stk-test-be/internal/models"
@@ -13,17 +15,18 @@ import (
 
 var DB *gorm.DB
 
-// Initialize initializes the database connection
+// Initialize initializes the database connection, retrying with exponential
+// backoff while the database is not reachable yet (e.g. a container that is
+// still starting)
 func Initialize(cfg *config.Config) (*gorm.DB, error) {
-	db, err := gorm.Open(
-		cfg.GetDialector(),
-		&gorm.Config{
-			Logger: logger.Default.LogMode(cfg.GetGormLogLevel()),
-		},
-	)
-
+	db, err := connectWithRetry(func() (*gorm.DB, error) {
+		return open(cfg.GetDialector(), cfg.GetGormLogLevel())
+	}, cfg.DBConnectMaxRetries, cfg.DBConnectBackoff)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return nil, err
+	}
+
+	if err := configurePool(db, cfg); err != nil {
 		return nil, err
 	}
 
@@ -33,16 +36,77 @@ func Initialize(cfg *config.Config) (*gorm.DB, error) {
 	return db, nil
 }
 
+// open opens a connection and pings it, so an unreachable database is
+// reported here rather than on the first query
+func open(dialector gorm.Dialector, logLevel logger.LogLevel) (*gorm.DB, error) {
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logger.Default.LogMode(logLevel),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// configurePool applies the connection pool limits from the config
+func configurePool(db *gorm.DB, cfg *config.Config) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	sqlDB.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	return nil
+}
+
+// connectWithRetry calls connect until it succeeds, retrying up to
+// maxRetries times and doubling the wait after every failed attempt
+func connectWithRetry(connect func() (*gorm.DB, error), maxRetries int, backoff time.Duration) (*gorm.DB, error) {
+	wait := backoff
+	for attempt := 1; ; attempt++ {
+		db, err := connect()
+		if err == nil {
+			return db, nil
+		}
+
+		if attempt > maxRetries {
+			return nil, fmt.Errorf("failed to connect to database after %d attempts: %w", attempt, err)
+		}
+
+		log.Printf("Database connection attempt %d/%d failed: %v; retrying in %s", attempt, maxRetries+1, err, wait)
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
 // Migrate runs database migrations
 // Uses AutoMigrate for development, SQL migrations for production
 func Migrate(db *gorm.DB, cfg *config.Config) error {
 	log.Println("Running database migrations...")
 
-	if cfg.IsDevelopment() {
+	if cfg.IsDevelopment() && db.Dialector.Name() == "mysql" {
+		// The model tags describe the Postgres/SQLite schema (UUID columns and
+		// a partial unique index on path), which MySQL cannot create
+		log.Println("AutoMigrate is not supported on MySQL; run with -migrate sql to apply migrations/mysql")
+	} else if cfg.IsDevelopment() {
 		// Use AutoMigrate for fast development iteration
 		log.Println("Using AutoMigrate for development mode")
 		if err := db.AutoMigrate(
 			&models.Menu{},
+			&models.MenuPreset{},
 		); err != nil {
 			log.Fatalf("Failed to run migrations: %v", err)
 			return err
@@ -59,17 +123,46 @@ func Migrate(db *gorm.DB, cfg *config.Config) error {
 }
 
 // MigrateFromFS runs migrations from embedded filesystem
-func MigrateFromFS(db *gorm.DB, migrations embed.FS) error {
+func MigrateFromFS(db *gorm.DB, migrations fs.FS) error {
 	migrator := NewMigrator(db)
 	return migrator.RunMigrationsFromFS(migrations)
 }
 
-// SeedFromFS seeds the database from embedded filesystem
-func SeedFromFS(db *gorm.DB, seeds embed.FS) error {
-	seeder := NewSeeder(db)
+// PlanFromFS lists the pending migrations in the embedded filesystem
+// without applying them
+func PlanFromFS(db *gorm.DB, migrations fs.FS) ([]string, error) {
+	migrator := NewMigrator(db)
+	return migrator.Plan(migrations)
+}
+
+// RollbackFromFS rolls back the last applied migration using the down
+// migrations in the embedded filesystem
+func RollbackFromFS(db *gorm.DB, migrations fs.FS) error {
+	migrator := NewMigrator(db)
+	return migrator.RollbackLastMigration(migrations)
+}
+
+// SeedFromFS seeds the database from embedded filesystem; in strict mode the
+// first failing seed aborts seeding
+func SeedFromFS(db *gorm.DB, seeds fs.FS, strict bool) error {
+	seeder := NewSeeder(db).WithStrict(strict)
 	return seeder.SeedFromFS(seeds)
 }
 
+// RollbackSeedFromFS rolls back one applied seed using its down seed in the
+// embedded filesystem
+func RollbackSeedFromFS(db *gorm.DB, seeds fs.FS, name string) error {
+	seeder := NewSeeder(db)
+	return seeder.RollbackSeed(seeds, name)
+}
+
+// ClearSeeds forgets every applied seed and empties the given tables, so the
+// seeds run again from scratch (development only!)
+func ClearSeeds(db *gorm.DB, tables ...string) error {
+	seeder := NewSeeder(db)
+	return seeder.ClearSeeds(tables...)
+}
+
 // Close closes the database connection
 func Close() error {
 	sqlDB, err := DB.DB()
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/config"
+	"github.com/andhikadk/stk-test-be/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	_ "modernc.org/sqlite"
+)
+
+func TestConnectWithRetry_UnreachableDatabase(t *testing.T) {
+	// The directory does not exist, so every ping fails
+	dialector := sqlite.Dialector{DriverName: "sqlite", DSN: "file:/nonexistent-dir/stk_test.db"}
+
+	attempts := 0
+	db, err := connectWithRetry(func() (*gorm.DB, error) {
+		attempts++
+		return open(dialector, logger.Silent)
+	}, 3, time.Millisecond)
+
+	if err == nil {
+		t.Fatal("Expected connecting to an unreachable database to fail")
+	}
+	if db != nil {
+		t.Error("Expected no connection to be returned")
+	}
+	if attempts != 4 {
+		t.Errorf("Expected 1 attempt and 3 retries, got %d attempts", attempts)
+	}
+}
+
+func TestConnectWithRetry_SucceedsAfterFailures(t *testing.T) {
+	dialector := sqlite.Dialector{DriverName: "sqlite", DSN: "file::memory:"}
+	unreachable := sqlite.Dialector{DriverName: "sqlite", DSN: "file:/nonexistent-dir/stk_test.db"}
+
+	attempts := 0
+	db, err := connectWithRetry(func() (*gorm.DB, error) {
+		attempts++
+		if attempts < 3 {
+			return open(unreachable, logger.Silent)
+		}
+		return open(dialector, logger.Silent)
+	}, 5, time.Millisecond)
+
+	if err != nil {
+		t.Fatalf("Expected connection to succeed, got %v", err)
+	}
+	defer func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestConfigurePool(t *testing.T) {
+	db, err := open(sqlite.Dialector{DriverName: "sqlite", DSN: "file::memory:"}, logger.Silent)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	cfg := &config.Config{
+		DBMaxOpenConns:    3,
+		DBMaxIdleConns:    1,
+		DBConnMaxLifetime: time.Minute,
+	}
+	if err := configurePool(db, cfg); err != nil {
+		t.Fatalf("Failed to configure pool: %v", err)
+	}
+
+	if got := sqlDB.Stats().MaxOpenConnections; got != 3 {
+		t.Errorf("Expected max open connections 3, got %d", got)
+	}
+
+	// Releasing three connections keeps only one of them idle
+	ctx := context.Background()
+	conns := make([]*sql.Conn, 0, 3)
+	for i := 0; i < 3; i++ {
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get connection: %v", err)
+		}
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		conn.Close()
+	}
+
+	if got := sqlDB.Stats().Idle; got != 1 {
+		t.Errorf("Expected 1 idle connection, got %d", got)
+	}
+}
+
+func TestMigrate_DevelopmentCreatesMenusTable(t *testing.T) {
+	db, err := open(sqlite.Dialector{DriverName: "sqlite", DSN: "file::memory:"}, logger.Silent)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	// Every pooled connection would otherwise get its own empty database
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := Migrate(db, &config.Config{Env: "development"}); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	if !db.Migrator().HasTable(&models.Menu{}) {
+		t.Fatal("Expected the menus table to be created")
+	}
+	if !db.Migrator().HasIndex(&models.Menu{}, "idx_menus_parent_id") {
+		t.Error("Expected an index on menus.parent_id")
+	}
+	if !db.Migrator().HasConstraint(&models.Menu{}, "Children") {
+		t.Error("Expected the parent_id foreign key to be created")
+	}
+}
@@ -1,39 +1,69 @@
 package database
 
 import (
-	"embed"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
-	"path/filepath"
+	"path"
 	"sort"
 	"strings"
 
 	"gorm.io/gorm"
 )
 
+// Migrations come in pairs named NNNN_name.up.sql and NNNN_name.down.sql
+const (
+	upSuffix   = ".up.sql"
+	downSuffix = ".down.sql"
+)
+
+// ErrMigrationsNotInitialized is returned by read-only checks when no
+// migration has ever been run against the database
+var ErrMigrationsNotInitialized = errors.New("migrations not initialized: migration_versions table does not exist")
+
 // MigrationFile represents a single migration file
 type MigrationFile struct {
 	Version string
 	SQL     string
 }
 
+// ChecksumMismatch describes an applied migration whose file has drifted
+type ChecksumMismatch struct {
+	Version  string
+	Expected string
+	Actual   string
+}
+
 // Migrator handles SQL migrations
 type Migrator struct {
 	db    *gorm.DB
-	files embed.FS
+	files fs.FS
 	path  string
 }
 
-// NewMigrator creates a new migrator instance
+// NewMigrator creates a new migrator instance. MySQL reads its migrations
+// from migrations/mysql, since the shared ones rely on Postgres-only DDL
+// such as the UUID type and partial indexes
 func NewMigrator(db *gorm.DB) *Migrator {
 	return &Migrator{
 		db:   db,
-		path: "migrations",
+		path: migrationsDir(db),
 	}
 }
 
+// migrationsDir returns the directory holding the migrations for db's dialect
+func migrationsDir(db *gorm.DB) string {
+	if db.Dialector.Name() == "mysql" {
+		return "migrations/mysql"
+	}
+	return "migrations"
+}
+
 // RunMigrationsFromFS runs migrations from embedded filesystem
-func (m *Migrator) RunMigrationsFromFS(files embed.FS) error {
+func (m *Migrator) RunMigrationsFromFS(files fs.FS) error {
 	m.files = files
 
 	// Ensure migration_versions table exists
@@ -41,29 +71,91 @@ func (m *Migrator) RunMigrationsFromFS(files embed.FS) error {
 		return err
 	}
 
+	migrations, err := m.pendingMigrations(files)
+	if err != nil {
+		return err
+	}
+
+	// Execute migrations in order
+	for _, migration := range migrations {
+		if err := m.executeMigration(&migration); err != nil {
+			return err
+		}
+	}
+
+	log.Println("All migrations completed successfully")
+	return nil
+}
+
+// Plan returns, in execution order, the migrations a run would apply,
+// without executing any SQL or creating the migration_versions table
+func (m *Migrator) Plan(files fs.FS) ([]string, error) {
+	migrations, err := m.pendingMigrations(files)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, len(migrations))
+	for i, migration := range migrations {
+		versions[i] = migration.Version
+	}
+	return versions, nil
+}
+
+// pendingMigrations reads the up migrations that have not been applied yet,
+// sorted by version. It fails if an applied migration's file has changed.
+func (m *Migrator) pendingMigrations(files fs.FS) ([]MigrationFile, error) {
 	// Read migration files
-	entries, err := files.ReadDir("migrations")
+	entries, err := fs.ReadDir(files, m.path)
 	if err != nil {
-		return fmt.Errorf("failed to read migrations directory: %w", err)
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
 	}
 
-	// Get SQL migration files (numbered .sql files)
+	// Before the first run nothing is applied and there is nothing to check
+	applied := map[string]bool{}
+	stored := map[string]string{}
+	if m.db.Migrator().HasTable("migration_versions") {
+		versions, err := m.GetAppliedMigrations()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+		for _, version := range versions {
+			applied[version] = true
+		}
+		if stored, err = m.getStoredChecksums(); err != nil {
+			return nil, fmt.Errorf("failed to read migration checksums: %w", err)
+		}
+	}
+
+	// Get SQL migration files (NNNN_name.up.sql)
 	var migrations []MigrationFile
 	for _, entry := range entries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
 			continue
 		}
 
-		// Check if migration is already applied
-		if m.isMigrationApplied(entry.Name()) {
-			log.Printf("Migration %s already applied, skipping", entry.Name())
+		// Down migrations only run on rollback
+		if strings.HasSuffix(entry.Name(), downSuffix) {
 			continue
 		}
+		if !strings.HasSuffix(entry.Name(), upSuffix) {
+			return nil, fmt.Errorf("migration %s must be named NNNN_name%s", entry.Name(), upSuffix)
+		}
 
 		// Read migration file
-		content, err := files.ReadFile(filepath.Join("migrations", entry.Name()))
+		content, err := fs.ReadFile(files, path.Join(m.path, entry.Name()))
 		if err != nil {
-			return fmt.Errorf("failed to read migration file %s: %w", entry.Name(), err)
+			return nil, fmt.Errorf("failed to read migration file %s: %w", entry.Name(), err)
+		}
+
+		// Check if migration is already applied, and unchanged since then;
+		// migrations applied before checksums were tracked cannot be checked
+		if applied[entry.Name()] {
+			if sum, ok := stored[entry.Name()]; ok && sum != checksum(string(content)) {
+				return nil, fmt.Errorf("migration %s has been modified after being applied", entry.Name())
+			}
+			log.Printf("Migration %s already applied, skipping", entry.Name())
+			continue
 		}
 
 		migrations = append(migrations, MigrationFile{
@@ -77,29 +169,30 @@ func (m *Migrator) RunMigrationsFromFS(files embed.FS) error {
 		return migrations[i].Version < migrations[j].Version
 	})
 
-	// Execute migrations in order
-	for _, migration := range migrations {
-		if err := m.executeMigration(&migration); err != nil {
-			return err
-		}
-	}
-
-	log.Println("All migrations completed successfully")
-	return nil
+	return migrations, nil
 }
 
-// executeMigration executes a single migration
+// executeMigration runs a single migration and records it in one
+// transaction, so a failed migration leaves no record and, on databases with
+// transactional DDL such as PostgreSQL, no partial schema change either.
+// MySQL commits each DDL statement implicitly, so keep its migrations to one
+// statement where possible.
 func (m *Migrator) executeMigration(migration *MigrationFile) error {
 	log.Printf("Running migration: %s", migration.Version)
 
-	// Execute SQL
-	if err := m.db.Exec(migration.SQL).Error; err != nil {
-		return fmt.Errorf("failed to execute migration %s: %w", migration.Version, err)
-	}
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.SQL).Error; err != nil {
+			return fmt.Errorf("failed to execute migration %s: %w", migration.Version, err)
+		}
+
+		if err := recordMigration(tx, migration.Version, checksum(migration.SQL)); err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", migration.Version, err)
+		}
 
-	// Record migration as applied
-	if err := m.recordMigration(migration.Version); err != nil {
-		return fmt.Errorf("failed to record migration %s: %w", migration.Version, err)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	log.Printf("Migration %s completed successfully", migration.Version)
@@ -108,45 +201,205 @@ func (m *Migrator) executeMigration(migration *MigrationFile) error {
 
 // ensureMigrationTable ensures the migration versions table exists
 func (m *Migrator) ensureMigrationTable() error {
-	return m.db.Exec(`
+	if err := m.db.Exec(`
 		CREATE TABLE IF NOT EXISTS migration_versions (
 			id SERIAL PRIMARY KEY,
 			version VARCHAR(50) NOT NULL UNIQUE,
+			checksum VARCHAR(64),
 			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)
-	`).Error
+	`).Error; err != nil {
+		return err
+	}
+
+	// Tables created before checksums were tracked need the column added
+	if !m.db.Migrator().HasColumn("migration_versions", "checksum") {
+		return m.db.Exec("ALTER TABLE migration_versions ADD COLUMN checksum VARCHAR(64)").Error
+	}
+
+	return nil
 }
 
 // recordMigration records a migration as applied
-func (m *Migrator) recordMigration(version string) error {
-	return m.db.Exec(
-		"INSERT INTO migration_versions (version) VALUES (?)",
+func recordMigration(tx *gorm.DB, version, sum string) error {
+	return tx.Exec(
+		"INSERT INTO migration_versions (version, checksum) VALUES (?, ?)",
 		version,
+		sum,
 	).Error
 }
 
-// isMigrationApplied checks if a migration has been applied
-func (m *Migrator) isMigrationApplied(version string) bool {
-	var count int64
-	m.db.Table("migration_versions").
-		Where("version = ?", version).
-		Count(&count)
-	return count > 0
+// checksum returns the hex-encoded SHA-256 of a migration's SQL
+func checksum(sql string) string {
+	sum := sha256.Sum256([]byte(sql))
+	return hex.EncodeToString(sum[:])
 }
 
-// GetAppliedMigrations returns all applied migrations
+// GetAppliedMigrations returns all applied migrations, with versions
+// recorded under the legacy NNN_name.sql naming reported by their current
+// NNNN_name.up.sql file name
 func (m *Migrator) GetAppliedMigrations() ([]string, error) {
 	var versions []string
 	err := m.db.Table("migration_versions").
 		Order("applied_at ASC").
 		Pluck("version", &versions).Error
+	for i, version := range versions {
+		versions[i] = currentVersionName(version)
+	}
 	return versions, err
 }
 
-// RollbackLastMigration rolls back the last applied migration
-func (m *Migrator) RollbackLastMigration() error {
-	// Note: This is a simplified implementation
-	// For proper rollback, you would need down migrations
-	log.Println("Rollback functionality requires down migrations to be implemented")
-	return fmt.Errorf("rollback not fully implemented")
+// currentVersionName maps a version recorded before migrations were renamed
+// to the NNNN_name.up.sql convention, e.g. 001_create_menus_table.sql, to
+// its current file name; other versions are returned unchanged
+func currentVersionName(version string) string {
+	if strings.HasSuffix(version, upSuffix) || !strings.HasSuffix(version, ".sql") {
+		return version
+	}
+	number, name, ok := strings.Cut(strings.TrimSuffix(version, ".sql"), "_")
+	if !ok {
+		return version
+	}
+	if len(number) < 4 {
+		number = strings.Repeat("0", 4-len(number)) + number
+	}
+	return number + "_" + name + upSuffix
+}
+
+// getStoredChecksums returns the recorded checksum of each applied migration
+func (m *Migrator) getStoredChecksums() (map[string]string, error) {
+	// Tables created before checksums were tracked have nothing to compare
+	if !m.db.Migrator().HasColumn("migration_versions", "checksum") {
+		return map[string]string{}, nil
+	}
+
+	var rows []struct {
+		Version  string
+		Checksum *string
+	}
+	if err := m.db.Table("migration_versions").
+		Select("version", "checksum").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	checksums := make(map[string]string, len(rows))
+	for _, row := range rows {
+		if row.Checksum != nil {
+			checksums[currentVersionName(row.Version)] = *row.Checksum
+		}
+	}
+	return checksums, nil
+}
+
+// VerifyChecksumsFromFS compares the stored checksum of every applied
+// migration with the current file content and returns any drift. It only
+// reads: a database that was never migrated gets ErrMigrationsNotInitialized.
+func (m *Migrator) VerifyChecksumsFromFS(files fs.FS) ([]ChecksumMismatch, error) {
+	if !m.db.Migrator().HasTable("migration_versions") {
+		return nil, ErrMigrationsNotInitialized
+	}
+
+	versions, err := m.GetAppliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := m.getStoredChecksums()
+	if err != nil {
+		return nil, err
+	}
+
+	var mismatches []ChecksumMismatch
+	for _, version := range versions {
+		expected := stored[version]
+
+		// Migrations applied before checksums were tracked cannot be verified
+		if expected == "" {
+			log.Printf("Migration %s has no recorded checksum, skipping", version)
+			continue
+		}
+
+		actual := ""
+		content, err := fs.ReadFile(files, path.Join(m.path, version))
+		if err == nil {
+			actual = checksum(string(content))
+		}
+
+		if actual != expected {
+			mismatches = append(mismatches, ChecksumMismatch{
+				Version:  version,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+
+	return mismatches, nil
+}
+
+// downMigrationName returns the down migration paired with an up migration
+func downMigrationName(version string) string {
+	return strings.TrimSuffix(version, upSuffix) + downSuffix
+}
+
+// getLastAppliedMigration returns the most recently applied migration, or an
+// empty string when none has been applied
+func (m *Migrator) getLastAppliedMigration() (string, error) {
+	var versions []string
+	err := m.db.Table("migration_versions").
+		Order("applied_at DESC").
+		Order("version DESC").
+		Limit(1).
+		Pluck("version", &versions).Error
+	if err != nil || len(versions) == 0 {
+		return "", err
+	}
+	return versions[0], nil
+}
+
+// RollbackLastMigration runs the down migration of the last applied
+// migration and removes it from migration_versions in one transaction
+func (m *Migrator) RollbackLastMigration(files fs.FS) error {
+	m.files = files
+
+	if err := m.ensureMigrationTable(); err != nil {
+		return err
+	}
+
+	version, err := m.getLastAppliedMigration()
+	if err != nil {
+		return fmt.Errorf("failed to find last applied migration: %w", err)
+	}
+	if version == "" {
+		return fmt.Errorf("no applied migrations to roll back")
+	}
+
+	// version stays the recorded name, which the DELETE below must match;
+	// the down file follows the current naming
+	downName := downMigrationName(currentVersionName(version))
+	content, err := fs.ReadFile(files, path.Join(m.path, downName))
+	if err != nil {
+		return fmt.Errorf("migration %s has no down migration %s: %w", version, downName, err)
+	}
+
+	log.Printf("Rolling back migration: %s", version)
+
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(string(content)).Error; err != nil {
+			return fmt.Errorf("failed to execute down migration %s: %w", downName, err)
+		}
+
+		if err := tx.Exec("DELETE FROM migration_versions WHERE version = ?", version).Error; err != nil {
+			return fmt.Errorf("failed to remove migration record %s: %w", version, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Migration %s rolled back successfully", version)
+	return nil
 }
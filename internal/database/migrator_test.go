@@ -0,0 +1,317 @@
+package database_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andhikadk/stk-test-be/internal/database"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+)
+
+func migrationFS(files map[string]string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for name, sql := range files {
+		fsys["migrations/"+name] = &fstest.MapFile{Data: []byte(sql)}
+	}
+	return fsys
+}
+
+func TestVerifyChecksums_NoDrift(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+	})
+
+	migrator := database.NewMigrator(db)
+	if err := migrator.RunMigrationsFromFS(fsys); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	mismatches, err := migrator.VerifyChecksumsFromFS(fsys)
+	if err != nil {
+		t.Fatalf("Failed to verify checksums: %v", err)
+	}
+
+	testutil.AssertLen(t, mismatches, 0)
+}
+
+func TestVerifyChecksums_DetectsTamperedFile(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+		"0002_create_gadgets.up.sql": "CREATE TABLE gadgets (id INTEGER PRIMARY KEY);",
+	})
+
+	migrator := database.NewMigrator(db)
+	if err := migrator.RunMigrationsFromFS(fsys); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	fsys["migrations/0002_create_gadgets.up.sql"] = &fstest.MapFile{
+		Data: []byte("CREATE TABLE gadgets (id INTEGER PRIMARY KEY, name TEXT);"),
+	}
+
+	mismatches, err := migrator.VerifyChecksumsFromFS(fsys)
+	if err != nil {
+		t.Fatalf("Failed to verify checksums: %v", err)
+	}
+
+	testutil.AssertLen(t, mismatches, 1)
+	testutil.AssertEqual(t, "0002_create_gadgets.up.sql", mismatches[0].Version)
+	testutil.AssertNotEqual(t, mismatches[0].Expected, mismatches[0].Actual)
+}
+
+func TestRollbackLastMigration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql":   "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+		"0001_create_widgets.down.sql": "DROP TABLE widgets;",
+		"0002_create_gadgets.up.sql":   "CREATE TABLE gadgets (id INTEGER PRIMARY KEY);",
+		"0002_create_gadgets.down.sql": "DROP TABLE gadgets;",
+	})
+
+	migrator := database.NewMigrator(db)
+	if err := migrator.RunMigrationsFromFS(fsys); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	applied, err := migrator.GetAppliedMigrations()
+	if err != nil {
+		t.Fatalf("Failed to get applied migrations: %v", err)
+	}
+	testutil.AssertLen(t, applied, 2)
+
+	if err := migrator.RollbackLastMigration(fsys); err != nil {
+		t.Fatalf("Failed to roll back migration: %v", err)
+	}
+
+	testutil.AssertEqual(t, false, db.Migrator().HasTable("gadgets"))
+	testutil.AssertEqual(t, true, db.Migrator().HasTable("widgets"))
+
+	applied, err = migrator.GetAppliedMigrations()
+	if err != nil {
+		t.Fatalf("Failed to get applied migrations: %v", err)
+	}
+	testutil.AssertLen(t, applied, 1)
+	testutil.AssertEqual(t, "0001_create_widgets.up.sql", applied[0])
+
+	// Rolled back migrations are applied again on the next run
+	if err := migrator.RunMigrationsFromFS(fsys); err != nil {
+		t.Fatalf("Failed to re-run migrations: %v", err)
+	}
+	testutil.AssertEqual(t, true, db.Migrator().HasTable("gadgets"))
+}
+
+func TestRollbackLastMigration_MissingDownFile(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+	})
+
+	migrator := database.NewMigrator(db)
+	if err := migrator.RunMigrationsFromFS(fsys); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	err := migrator.RollbackLastMigration(fsys)
+	testutil.AssertNotNil(t, err)
+	testutil.AssertContains(t, err.Error(), "0001_create_widgets.down.sql")
+
+	// Nothing changed because the rollback never started
+	testutil.AssertEqual(t, true, db.Migrator().HasTable("widgets"))
+	applied, _ := migrator.GetAppliedMigrations()
+	testutil.AssertLen(t, applied, 1)
+}
+
+func TestRunMigrations_RejectsModifiedMigration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+	})
+
+	migrator := database.NewMigrator(db)
+	if err := migrator.RunMigrationsFromFS(fsys); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// An unchanged re-run is a no-op
+	if err := migrator.RunMigrationsFromFS(fsys); err != nil {
+		t.Fatalf("Failed to re-run migrations: %v", err)
+	}
+
+	fsys["migrations/0001_create_widgets.up.sql"] = &fstest.MapFile{
+		Data: []byte("CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT);"),
+	}
+	fsys["migrations/0002_create_gadgets.up.sql"] = &fstest.MapFile{
+		Data: []byte("CREATE TABLE gadgets (id INTEGER PRIMARY KEY);"),
+	}
+
+	err := migrator.RunMigrationsFromFS(fsys)
+	testutil.AssertNotNil(t, err)
+	testutil.AssertEqual(t, "migration 0001_create_widgets.up.sql has been modified after being applied", err.Error())
+
+	// Pending migrations are not applied once drift is found
+	testutil.AssertEqual(t, false, db.Migrator().HasTable("gadgets"))
+}
+
+func TestPlan_ListsOnlyPendingMigrationsInOrder(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+	})
+
+	migrator := database.NewMigrator(db)
+	if err := migrator.RunMigrationsFromFS(fsys); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	fsys["migrations/0003_create_gizmos.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE gizmos (id INTEGER PRIMARY KEY);")}
+	fsys["migrations/0003_create_gizmos.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE gizmos;")}
+	fsys["migrations/0002_create_gadgets.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE gadgets (id INTEGER PRIMARY KEY);")}
+
+	plan, err := migrator.Plan(fsys)
+	if err != nil {
+		t.Fatalf("Failed to plan migrations: %v", err)
+	}
+
+	testutil.AssertLen(t, plan, 2)
+	testutil.AssertEqual(t, "0002_create_gadgets.up.sql", plan[0])
+	testutil.AssertEqual(t, "0003_create_gizmos.up.sql", plan[1])
+
+	// Planning executes nothing
+	testutil.AssertEqual(t, false, db.Migrator().HasTable("gadgets"))
+	applied, _ := migrator.GetAppliedMigrations()
+	testutil.AssertLen(t, applied, 1)
+}
+
+func TestPlan_BeforeFirstRun(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+	})
+
+	plan, err := database.NewMigrator(db).Plan(fsys)
+	if err != nil {
+		t.Fatalf("Failed to plan migrations: %v", err)
+	}
+
+	testutil.AssertLen(t, plan, 1)
+	testutil.AssertEqual(t, false, db.Migrator().HasTable("migration_versions"))
+}
+
+func TestVerifyChecksums_NotInitialized(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+	})
+
+	_, err := database.NewMigrator(db).VerifyChecksumsFromFS(fsys)
+
+	testutil.AssertEqual(t, database.ErrMigrationsNotInitialized, err)
+	testutil.AssertEqual(t, false, db.Migrator().HasTable("migration_versions"), "Verify should not create the table")
+}
+
+func TestRunMigrations_RollsBackFailedMigration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY); INSERT INTO missing_table (id) VALUES (1);",
+	})
+
+	migrator := database.NewMigrator(db)
+	err := migrator.RunMigrationsFromFS(fsys)
+	testutil.AssertNotNil(t, err)
+
+	testutil.AssertEqual(t, false, db.Migrator().HasTable("widgets"), "The failed migration should leave no partial schema")
+	applied, _ := migrator.GetAppliedMigrations()
+	testutil.AssertLen(t, applied, 0)
+}
+
+func TestRunMigrations_RejectsUnpairedFileName(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+	})
+
+	err := database.NewMigrator(db).RunMigrationsFromFS(fsys)
+	testutil.AssertNotNil(t, err)
+	testutil.AssertContains(t, err.Error(), "must be named NNNN_name.up.sql")
+	testutil.AssertEqual(t, false, db.Migrator().HasTable("widgets"))
+}
+
+func TestRunMigrations_LegacyVersionNamesCountAsApplied(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	sql := "CREATE TABLE widgets (id INTEGER PRIMARY KEY);"
+
+	// A database migrated by 001_create_widgets.sql, before the rename
+	db.Exec(sql)
+	db.Exec("CREATE TABLE migration_versions (id INTEGER PRIMARY KEY, version VARCHAR(50) NOT NULL UNIQUE, checksum VARCHAR(64), applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP)")
+	db.Exec("INSERT INTO migration_versions (version) VALUES (?)", "001_create_widgets.sql")
+
+	fsys := migrationFS(map[string]string{
+		"0001_create_widgets.up.sql":   sql,
+		"0001_create_widgets.down.sql": "DROP TABLE widgets;",
+	})
+
+	migrator := database.NewMigrator(db)
+	if err := migrator.RunMigrationsFromFS(fsys); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	applied, err := migrator.GetAppliedMigrations()
+	if err != nil {
+		t.Fatalf("Failed to get applied migrations: %v", err)
+	}
+	testutil.AssertEqual(t, []string{"0001_create_widgets.up.sql"}, applied)
+
+	if err := migrator.RollbackLastMigration(fsys); err != nil {
+		t.Fatalf("Failed to roll back migration: %v", err)
+	}
+	testutil.AssertEqual(t, false, db.Migrator().HasTable("widgets"))
+	applied, _ = migrator.GetAppliedMigrations()
+	testutil.AssertLen(t, applied, 0)
+}
+
+// Every shipped migration needs a MySQL counterpart, or a MySQL database ends
+// up with a different schema than the one the code expects
+func TestShippedMigrations_MySQLMirrorsShared(t *testing.T) {
+	migrationNames := func(dir string) []string {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", dir, err)
+		}
+		var names []string
+		for _, entry := range entries {
+			if !entry.IsDir() && filepath.Ext(entry.Name()) == ".sql" {
+				names = append(names, entry.Name())
+			}
+		}
+		return names
+	}
+
+	shared := filepath.Join("..", "..", "migrations")
+	testutil.AssertEqual(t, migrationNames(shared), migrationNames(filepath.Join(shared, "mysql")))
+}
@@ -0,0 +1,13 @@
+package database
+
+import "gorm.io/gorm"
+
+// CaseInsensitiveLike adds a case-insensitive LIKE condition on column to db.
+// Postgres gets ILIKE; other drivers, including MySQL and the SQLite used by
+// the tests, get the portable LOWER(column) LIKE LOWER(?) form.
+func CaseInsensitiveLike(db *gorm.DB, column, pattern string) *gorm.DB {
+	if db.Dialector.Name() == "postgres" {
+		return db.Where(column+" ILIKE ?", pattern)
+	}
+	return db.Where("LOWER("+column+") LIKE LOWER(?)", pattern)
+}
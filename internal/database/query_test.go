@@ -0,0 +1,25 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/database"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+)
+
+func TestCaseInsensitiveLike(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	testutil.CreateMenuWithPath(db, "User Settings", "/settings/users", "icon-users", nil)
+	testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+
+	var menus []models.Menu
+	if err := database.CaseInsensitiveLike(db, "title", "%SETTINGS%").Find(&menus).Error; err != nil {
+		t.Fatalf("Failed to search menus: %v", err)
+	}
+
+	testutil.AssertLen(t, menus, 1)
+	testutil.AssertEqual(t, "User Settings", menus[0].Title)
+}
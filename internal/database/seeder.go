@@ -1,18 +1,21 @@
 package database
 
 import (
-	"embed"
 	"fmt"
+	"io/fs"
 	"log"
-	"path/filepath"
+	"path"
 	"strings"
 
 	"gorm.io/gorm"
 )
 
+const seedsPath = "migrations/seeds"
+
 // Seeder handles database seeding
 type Seeder struct {
-	db *gorm.DB
+	db     *gorm.DB
+	strict bool
 }
 
 // NewSeeder creates a new seeder instance
@@ -22,15 +25,23 @@ func NewSeeder(db *gorm.DB) *Seeder {
 	}
 }
 
+// WithStrict returns a copy of the seeder that stops at the first failing
+// seed and returns its error instead of logging it and moving on
+func (s *Seeder) WithStrict(strict bool) *Seeder {
+	clone := *s
+	clone.strict = strict
+	return &clone
+}
+
 // SeedFromFS seeds database from embedded filesystem
-func (s *Seeder) SeedFromFS(files embed.FS) error {
+func (s *Seeder) SeedFromFS(files fs.FS) error {
 	// Create seed tracking table if not exists
 	if err := s.ensureSeedTable(); err != nil {
 		return err
 	}
 
 	// Read seed files
-	entries, err := files.ReadDir("migrations/seeds")
+	entries, err := fs.ReadDir(files, seedsPath)
 	if err != nil {
 		log.Println("No seeds directory found, skipping seeding")
 		return nil
@@ -42,6 +53,11 @@ func (s *Seeder) SeedFromFS(files embed.FS) error {
 			continue
 		}
 
+		// Down seeds only run on rollback
+		if strings.HasSuffix(entry.Name(), downSuffix) {
+			continue
+		}
+
 		// Check if seed is already applied
 		if s.isSeedApplied(entry.Name()) {
 			log.Printf("Seed %s already applied, skipping", entry.Name())
@@ -54,6 +70,9 @@ func (s *Seeder) SeedFromFS(files embed.FS) error {
 	// Execute seeds in order
 	for _, seedFile := range seedFiles {
 		if err := s.executeSeed(files, seedFile); err != nil {
+			if s.strict {
+				return err
+			}
 			log.Printf("Warning: Failed to execute seed %s: %v", seedFile, err)
 			// Don't fail completely if a seed fails
 			continue
@@ -64,30 +83,76 @@ func (s *Seeder) SeedFromFS(files embed.FS) error {
 	return nil
 }
 
-// executeSeed executes a single seed file
-func (s *Seeder) executeSeed(files embed.FS, seedFile string) error {
+// executeSeed executes a single seed file and records it in one
+// transaction, so a failed seed leaves nothing behind and can be re-run
+func (s *Seeder) executeSeed(files fs.FS, seedFile string) error {
 	log.Printf("Running seed: %s", seedFile)
 
 	// Read seed file
-	content, err := files.ReadFile(filepath.Join("migrations/seeds", seedFile))
+	content, err := fs.ReadFile(files, path.Join(seedsPath, seedFile))
 	if err != nil {
 		return fmt.Errorf("failed to read seed file %s: %w", seedFile, err)
 	}
 
-	// Execute SQL
-	if err := s.db.Exec(string(content)).Error; err != nil {
-		return fmt.Errorf("failed to execute seed %s: %w", seedFile, err)
-	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// Execute SQL
+		if err := tx.Exec(string(content)).Error; err != nil {
+			return fmt.Errorf("failed to execute seed %s: %w", seedFile, err)
+		}
 
-	// Record seed as applied
-	if err := s.recordSeed(seedFile); err != nil {
-		return fmt.Errorf("failed to record seed %s: %w", seedFile, err)
+		// Record seed as applied
+		if err := tx.Exec("INSERT INTO seed_versions (seed_name) VALUES (?)", seedFile).Error; err != nil {
+			return fmt.Errorf("failed to record seed %s: %w", seedFile, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	log.Printf("Seed %s completed successfully", seedFile)
 	return nil
 }
 
+// RollbackSeed undoes an applied seed by running its NNN_name.down.sql
+// companion and removing its seed_versions record in one transaction
+func (s *Seeder) RollbackSeed(files fs.FS, name string) error {
+	if err := s.ensureSeedTable(); err != nil {
+		return err
+	}
+
+	if !s.isSeedApplied(name) {
+		return fmt.Errorf("seed %s has not been applied", name)
+	}
+
+	downName := strings.TrimSuffix(name, ".sql") + downSuffix
+	content, err := fs.ReadFile(files, path.Join(seedsPath, downName))
+	if err != nil {
+		return fmt.Errorf("seed %s has no down seed %s: %w", name, downName, err)
+	}
+
+	log.Printf("Rolling back seed: %s", name)
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(string(content)).Error; err != nil {
+			return fmt.Errorf("failed to execute down seed %s: %w", downName, err)
+		}
+
+		if err := tx.Exec("DELETE FROM seed_versions WHERE seed_name = ?", name).Error; err != nil {
+			return fmt.Errorf("failed to remove seed record %s: %w", name, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Seed %s rolled back successfully", name)
+	return nil
+}
+
 // ensureSeedTable ensures the seed tracking table exists
 func (s *Seeder) ensureSeedTable() error {
 	return s.db.Exec(`
@@ -99,14 +164,6 @@ func (s *Seeder) ensureSeedTable() error {
 	`).Error
 }
 
-// recordSeed records a seed as applied
-func (s *Seeder) recordSeed(seedName string) error {
-	return s.db.Exec(
-		"INSERT INTO seed_versions (seed_name) VALUES (?)",
-		seedName,
-	).Error
-}
-
 // isSeedApplied checks if a seed has been applied
 func (s *Seeder) isSeedApplied(seedName string) bool {
 	var count int64
@@ -116,9 +173,22 @@ func (s *Seeder) isSeedApplied(seedName string) bool {
 	return count > 0
 }
 
-// ClearSeeds clears all applied seed records (development only!)
-func (s *Seeder) ClearSeeds() error {
-	return s.db.Exec("DELETE FROM seed_versions").Error
+// ClearSeeds clears all applied seed records (development only!). Rows in
+// the given tables are deleted in the same transaction, so the seeds can be
+// re-run against empty tables.
+func (s *Seeder) ClearSeeds(tables ...string) error {
+	if err := s.ensureSeedTable(); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range tables {
+			if err := tx.Exec("DELETE FROM " + tx.Statement.Quote(table)).Error; err != nil {
+				return fmt.Errorf("failed to clear table %s: %w", table, err)
+			}
+		}
+		return tx.Exec("DELETE FROM seed_versions").Error
+	})
 }
 
 // GetAppliedSeeds returns all applied seeds
@@ -0,0 +1,227 @@
+package database_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andhikadk/stk-test-be/internal/database"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/services"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+)
+
+func seedFS(files map[string]string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for name, sql := range files {
+		fsys["migrations/seeds/"+name] = &fstest.MapFile{Data: []byte(sql)}
+	}
+	return fsys
+}
+
+func brokenSeedFS() fstest.MapFS {
+	return seedFS(map[string]string{
+		"001_widgets.sql": "CREATE TABLE widgets (id INTEGER PRIMARY KEY);",
+		"002_broken.sql":  "INSERT INTO missing_table (id) VALUES (1);",
+		"003_gadgets.sql": "CREATE TABLE gadgets (id INTEGER PRIMARY KEY);",
+	})
+}
+
+func TestSeedFromFS_LenientContinuesPastFailures(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	seeder := database.NewSeeder(db)
+	if err := seeder.SeedFromFS(brokenSeedFS()); err != nil {
+		t.Fatalf("Expected lenient seeding to succeed, got %v", err)
+	}
+
+	testutil.AssertEqual(t, true, db.Migrator().HasTable("gadgets"))
+
+	seeds, err := seeder.GetAppliedSeeds()
+	if err != nil {
+		t.Fatalf("Failed to get applied seeds: %v", err)
+	}
+	testutil.AssertLen(t, seeds, 2)
+}
+
+func TestSeedFromFS_StrictFailsFast(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	seeder := database.NewSeeder(db).WithStrict(true)
+	err := seeder.SeedFromFS(brokenSeedFS())
+	testutil.AssertNotNil(t, err)
+	testutil.AssertContains(t, err.Error(), "002_broken.sql")
+
+	// Seeds after the failing one never ran
+	testutil.AssertEqual(t, false, db.Migrator().HasTable("gadgets"))
+
+	seeds, err := seeder.GetAppliedSeeds()
+	if err != nil {
+		t.Fatalf("Failed to get applied seeds: %v", err)
+	}
+	testutil.AssertLen(t, seeds, 1)
+	testutil.AssertEqual(t, "001_widgets.sql", seeds[0])
+}
+
+func TestRollbackSeed(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	if err := db.Exec("CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	fsys := seedFS(map[string]string{
+		"001_widgets.sql":      "INSERT INTO widgets (id, name) VALUES (1, 'a'), (2, 'b');",
+		"001_widgets.down.sql": "DELETE FROM widgets WHERE id IN (1, 2);",
+	})
+
+	seeder := database.NewSeeder(db)
+	if err := seeder.SeedFromFS(fsys); err != nil {
+		t.Fatalf("Failed to seed: %v", err)
+	}
+
+	var count int64
+	db.Table("widgets").Count(&count)
+	testutil.AssertEqual(t, int64(2), count)
+
+	if err := seeder.RollbackSeed(fsys, "001_widgets.sql"); err != nil {
+		t.Fatalf("Failed to roll back seed: %v", err)
+	}
+
+	db.Table("widgets").Count(&count)
+	testutil.AssertEqual(t, int64(0), count)
+
+	seeds, err := seeder.GetAppliedSeeds()
+	if err != nil {
+		t.Fatalf("Failed to get applied seeds: %v", err)
+	}
+	testutil.AssertLen(t, seeds, 0)
+
+	// A rolled back seed can be applied again
+	if err := seeder.SeedFromFS(fsys); err != nil {
+		t.Fatalf("Failed to re-seed: %v", err)
+	}
+	db.Table("widgets").Count(&count)
+	testutil.AssertEqual(t, int64(2), count)
+}
+
+func TestSeedFromFS_FailedSeedIsNotPartiallyApplied(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	if err := db.Exec("CREATE TABLE widgets (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	// The second statement hits the primary key, so the first is undone too
+	fsys := seedFS(map[string]string{
+		"001_widgets.sql": "INSERT INTO widgets (id) VALUES (1); INSERT INTO widgets (id) VALUES (1);",
+	})
+
+	err := database.NewSeeder(db).WithStrict(true).SeedFromFS(fsys)
+	testutil.AssertNotNil(t, err)
+
+	var count int64
+	db.Table("widgets").Count(&count)
+	testutil.AssertEqual(t, int64(0), count)
+}
+
+func TestClearSeeds_DeletesSeededRows(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	if err := db.Exec("CREATE TABLE widgets (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	fsys := seedFS(map[string]string{
+		"001_widgets.sql": "INSERT INTO widgets (id) VALUES (1);",
+	})
+
+	seeder := database.NewSeeder(db)
+	if err := seeder.SeedFromFS(fsys); err != nil {
+		t.Fatalf("Failed to seed: %v", err)
+	}
+
+	if err := seeder.ClearSeeds("widgets"); err != nil {
+		t.Fatalf("Failed to clear seeds: %v", err)
+	}
+
+	var count int64
+	db.Table("widgets").Count(&count)
+	testutil.AssertEqual(t, int64(0), count)
+
+	seeds, _ := seeder.GetAppliedSeeds()
+	testutil.AssertLen(t, seeds, 0)
+}
+
+// shippedSeedFS holds every seed in migrations/seeds, so strict seeding
+// fails if any of them does not fit the schema
+func shippedSeedFS(t *testing.T) fstest.MapFS {
+	t.Helper()
+	dir := filepath.Join("..", "..", "migrations", "seeds")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read seeds directory: %v", err)
+	}
+	files := map[string]string{}
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("Failed to read seed %s: %v", entry.Name(), err)
+		}
+		files[entry.Name()] = string(content)
+	}
+	return seedFS(files)
+}
+
+func TestSampleMenusSeed_BuildsTree(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	seeder := database.NewSeeder(db).WithStrict(true)
+	fsys := shippedSeedFS(t)
+	if err := seeder.SeedFromFS(fsys); err != nil {
+		t.Fatalf("Failed to seed menus: %v", err)
+	}
+	// Applied seeds are skipped, so seeding again changes nothing
+	if err := seeder.SeedFromFS(fsys); err != nil {
+		t.Fatalf("Failed to re-run seeds: %v", err)
+	}
+
+	tree, err := services.NewMenuService(db).GetMenuTree(services.TreeOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get menu tree: %v", err)
+	}
+
+	titles := func(menus []models.Menu) []string {
+		out := make([]string, len(menus))
+		for i, menu := range menus {
+			out[i] = menu.Title
+		}
+		return out
+	}
+
+	testutil.AssertEqual(t, []string{"Dashboard", "Content", "Users", "Settings"}, titles(tree))
+	testutil.AssertEqual(t, []string{"Pages", "Posts", "Media Library"}, titles(tree[1].Children))
+	testutil.AssertEqual(t, []string{"All Users", "Access Control"}, titles(tree[2].Children))
+	testutil.AssertEqual(t, []string{"Roles", "Permissions"}, titles(tree[2].Children[1].Children))
+	testutil.AssertEqual(t, []string{"General", "Security", "Integrations"}, titles(tree[3].Children))
+
+	report, err := services.NewMenuService(db).ValidateTree()
+	if err != nil {
+		t.Fatalf("Failed to validate tree: %v", err)
+	}
+	testutil.AssertEqual(t, true, report.Valid)
+
+	if err := seeder.RollbackSeed(fsys, "003_sample_menus.sql"); err != nil {
+		t.Fatalf("Failed to roll back menu seed: %v", err)
+	}
+	var count int64
+	db.Model(&models.Menu{}).Count(&count)
+	testutil.AssertEqual(t, int64(0), count)
+}
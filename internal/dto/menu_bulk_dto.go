@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxBulkCreateMenus caps how many menus one bulk create may carry
+const MaxBulkCreateMenus = 100
+
+// BulkCreateMenuItem is a CreateMenuRequest that may instead place itself
+// under an earlier item of the same batch by its array position
+type BulkCreateMenuItem struct {
+	CreateMenuRequest
+	ParentIndex *int `json:"parent_index,omitempty" example:"0"`
+}
+
+// BulkCreateMenusRequest is the array body of POST /api/menus/bulk
+type BulkCreateMenusRequest []BulkCreateMenuItem
+
+// Validate checks every item with the CreateMenuRequest rules. Field errors
+// are keyed by the item's position, e.g. "[2].title".
+func (r *BulkCreateMenusRequest) Validate() error {
+	var v ValidationError
+
+	if len(*r) == 0 {
+		v.Add("menus", "at least one menu is required")
+	} else if len(*r) > MaxBulkCreateMenus {
+		v.Add("menus", fmt.Sprintf("cannot create more than %d menus at once", MaxBulkCreateMenus))
+	}
+
+	for i, item := range *r {
+		var itemErr *ValidationError
+		if errors.As(item.CreateMenuRequest.Validate(), &itemErr) {
+			for _, field := range itemErr.order {
+				v.Add(fmt.Sprintf("[%d].%s", i, field), fmt.Sprintf("menu %d: %s", i, itemErr.Fields[field]))
+			}
+		}
+
+		if item.ParentIndex != nil {
+			if *item.ParentIndex < 0 || *item.ParentIndex >= i {
+				v.Add(fmt.Sprintf("[%d].parent_index", i), fmt.Sprintf("menu %d: parent_index must refer to an earlier menu in the batch", i))
+			}
+			if item.ParentID != nil {
+				v.Add(fmt.Sprintf("[%d].parent_index", i), fmt.Sprintf("menu %d: parent_id and parent_index cannot both be set", i))
+			}
+		}
+	}
+
+	return v.Err()
+}
@@ -1,99 +1,273 @@
 package dto
 
 import (
-	"errors"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/andhikadk/stk-test-be/config"
+	"github.com/andhikadk/stk-test-be/internal/models"
 	"github.com/google/uuid"
 )
 
+// menuTitleMax, menuPathMax and menuIconMax return the configured length
+// limits, falling back to the column sizes when no config is loaded
+func menuTitleMax() int {
+	if config.AppConfig != nil && config.AppConfig.MenuTitleMax > 0 {
+		return config.AppConfig.MenuTitleMax
+	}
+	return config.MenuTitleColumnSize
+}
+
+func menuPathMax() int {
+	if config.AppConfig != nil && config.AppConfig.MenuPathMax > 0 {
+		return config.AppConfig.MenuPathMax
+	}
+	return config.MenuPathColumnSize
+}
+
+func menuIconMax() int {
+	if config.AppConfig != nil && config.AppConfig.MenuIconMax > 0 {
+		return config.AppConfig.MenuIconMax
+	}
+	return config.MenuIconColumnSize
+}
+
 type CreateMenuRequest struct {
-	ParentID   *uuid.UUID `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000" format:"uuid"`
 	Title      string     `json:"title" example:"Dashboard"`
 	Path       *string    `json:"path,omitempty" example:"/dashboard"`
 	Icon       *string    `json:"icon,omitempty" example:"icon-dashboard"`
 	OrderIndex *int       `json:"order_index,omitempty" example:"0"`
+	IsActive   *bool      `json:"is_active,omitempty" example:"true"`
 }
 
 func (r *CreateMenuRequest) Validate() error {
-	if strings.TrimSpace(r.Title) == "" {
-		return errors.New("title is required and cannot be empty")
-	}
+	var v ValidationError
 
-	if len(r.Title) > 255 {
-		return errors.New("title cannot exceed 255 characters")
+	if strings.TrimSpace(r.Title) == "" {
+		v.Add("title", "title is required and cannot be empty")
+	} else if len(r.Title) > menuTitleMax() {
+		v.Add("title", fmt.Sprintf("title cannot exceed %d characters", menuTitleMax()))
 	}
 
-	if r.Path != nil && len(*r.Path) > 255 {
-		return errors.New("path cannot exceed 255 characters")
+	if r.Path != nil && len(*r.Path) > menuPathMax() {
+		v.Add("path", fmt.Sprintf("path cannot exceed %d characters", menuPathMax()))
 	}
 
-	if r.Icon != nil && len(*r.Icon) > 100 {
-		return errors.New("icon cannot exceed 100 characters")
+	if r.Icon != nil && len(*r.Icon) > menuIconMax() {
+		v.Add("icon", fmt.Sprintf("icon cannot exceed %d characters", menuIconMax()))
 	}
 
 	if r.OrderIndex != nil && *r.OrderIndex < 0 {
-		return errors.New("order_index must be a non-negative integer")
+		v.Add("order_index", "order_index must be a non-negative integer")
 	}
 
-	return nil
+	return v.Err()
+}
+
+// UpsertColumns returns the menu columns an upsert writes to an existing
+// menu: the title and every optional field the caller set
+func (r *CreateMenuRequest) UpsertColumns() []string {
+	columns := []string{"title"}
+	if r.ParentID != nil {
+		columns = append(columns, "parent_id")
+	}
+	if r.Icon != nil {
+		columns = append(columns, "icon")
+	}
+	if r.IsActive != nil {
+		columns = append(columns, "is_active")
+	}
+	if r.OrderIndex != nil {
+		columns = append(columns, "order_index")
+	}
+	return columns
 }
 
 type UpdateMenuRequest struct {
-	ParentID   *uuid.UUID `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000" format:"uuid"`
 	Title      *string    `json:"title,omitempty" example:"Dashboard"`
 	Path       *string    `json:"path,omitempty" example:"/dashboard"`
 	Icon       *string    `json:"icon,omitempty" example:"icon-dashboard"`
 	OrderIndex *int       `json:"order_index,omitempty" example:"0"`
+	IsActive   *bool      `json:"is_active,omitempty" example:"true"`
+
+	// present records which keys appeared in the request body so that an
+	// explicit null can be told apart from an omitted field
+	present map[string]bool
+}
+
+func (r *UpdateMenuRequest) UnmarshalJSON(data []byte) error {
+	type alias UpdateMenuRequest
+
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, (*alias)(r)); err != nil {
+		return err
+	}
+
+	r.present = make(map[string]bool, len(keys))
+	for key := range keys {
+		r.present[key] = true
+	}
+
+	return nil
+}
+
+// Has reports whether the field was sent in the request body, even as null
+func (r *UpdateMenuRequest) Has(field string) bool {
+	return r.present[field]
+}
+
+// ProvidedColumns returns the menu columns the caller explicitly set
+func (r *UpdateMenuRequest) ProvidedColumns() []string {
+	columns := make([]string, 0, 6)
+	for _, column := range []string{"parent_id", "title", "path", "icon", "is_active"} {
+		if r.Has(column) {
+			columns = append(columns, column)
+		}
+	}
+	// A null order_index leaves the position unchanged
+	if r.OrderIndex != nil {
+		columns = append(columns, "order_index")
+	}
+	return columns
 }
 
 func (r *UpdateMenuRequest) Validate() error {
+	var v ValidationError
+
+	if r.Has("title") && r.Title == nil {
+		v.Add("title", "title cannot be null")
+	}
+
+	if r.Has("is_active") && r.IsActive == nil {
+		v.Add("is_active", "is_active cannot be null")
+	}
+
 	if r.Title != nil {
 		trimmedTitle := strings.TrimSpace(*r.Title)
 		if trimmedTitle == "" {
-			return errors.New("title cannot be empty if provided")
-		}
-		if len(trimmedTitle) > 255 {
-			return errors.New("title cannot exceed 255 characters")
+			v.Add("title", "title cannot be empty if provided")
+		} else if len(trimmedTitle) > menuTitleMax() {
+			v.Add("title", fmt.Sprintf("title cannot exceed %d characters", menuTitleMax()))
 		}
 	}
 
-	if r.Path != nil && len(*r.Path) > 255 {
-		return errors.New("path cannot exceed 255 characters")
+	if r.Path != nil && len(*r.Path) > menuPathMax() {
+		v.Add("path", fmt.Sprintf("path cannot exceed %d characters", menuPathMax()))
 	}
 
-	if r.Icon != nil && len(*r.Icon) > 100 {
-		return errors.New("icon cannot exceed 100 characters")
+	if r.Icon != nil && len(*r.Icon) > menuIconMax() {
+		v.Add("icon", fmt.Sprintf("icon cannot exceed %d characters", menuIconMax()))
 	}
 
 	if r.OrderIndex != nil && *r.OrderIndex < 0 {
-		return errors.New("order_index must be a non-negative integer")
+		v.Add("order_index", "order_index must be a non-negative integer")
 	}
 
-	return nil
+	return v.Err()
 }
 
 type MoveMenuRequest struct {
-	ParentID *uuid.UUID `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000" format:"uuid"`
 }
 
 func (r *MoveMenuRequest) Validate() error {
 	return nil
 }
 
+type CloneMenuRequest struct {
+	ParentID *uuid.UUID `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000" format:"uuid"`
+}
+
+func (r *CloneMenuRequest) Validate() error {
+	return nil
+}
+
 type ReorderMenuRequest struct {
 	NewIndex int  `json:"new_index" example:"2"`
 	OldIndex *int `json:"old_index,omitempty" example:"0"`
 }
 
 func (r *ReorderMenuRequest) Validate() error {
+	var v ValidationError
+
 	if r.NewIndex < 0 {
-		return errors.New("new_index must be a non-negative integer")
+		v.Add("new_index", "new_index must be a non-negative integer")
 	}
 
 	if r.OldIndex != nil && *r.OldIndex < 0 {
-		return errors.New("old_index must be a non-negative integer if provided")
+		v.Add("old_index", "old_index must be a non-negative integer if provided")
 	}
 
-	return nil
+	return v.Err()
+}
+
+type ReorderSiblingsRequest struct {
+	ParentID   *uuid.UUID  `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000" format:"uuid"`
+	OrderedIDs []uuid.UUID `json:"ordered_ids"`
+}
+
+func (r *ReorderSiblingsRequest) Validate() error {
+	var v ValidationError
+
+	if len(r.OrderedIDs) == 0 {
+		v.Add("ordered_ids", "ordered_ids is required and cannot be empty")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(r.OrderedIDs))
+	for _, id := range r.OrderedIDs {
+		if seen[id] {
+			v.Add("ordered_ids", "ordered_ids cannot contain duplicates")
+			break
+		}
+		seen[id] = true
+	}
+
+	return v.Err()
+}
+
+type AssignMenuIconsRequest struct {
+	Assignments map[uuid.UUID]string `json:"assignments"`
+}
+
+func (r *AssignMenuIconsRequest) Validate() error {
+	var v ValidationError
+
+	if len(r.Assignments) == 0 {
+		v.Add("assignments", "assignments is required and cannot be empty")
+	}
+
+	ids := make([]uuid.UUID, 0, len(r.Assignments))
+	for id := range r.Assignments {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b uuid.UUID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	for _, id := range ids {
+		if len(r.Assignments[id]) > menuIconMax() {
+			v.Add("assignments."+id.String(), fmt.Sprintf("icon for %s cannot exceed %d characters", id, menuIconMax()))
+		}
+	}
+
+	return v.Err()
+}
+
+type AssignMenuIconsResponse struct {
+	Updated    int64       `json:"updated" example:"2"`
+	UnknownIDs []uuid.UUID `json:"unknown_ids"`
+}
+
+type MenuDetailResponse struct {
+	models.Menu
+	ChildCount      int64 `json:"child_count" example:"3"`
+	DescendantCount int64 `json:"descendant_count" example:"7"`
 }
@@ -0,0 +1,135 @@
+package dto_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/config"
+	"github.com/andhikadk/stk-test-be/internal/dto"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+)
+
+func withLengthLimits(t *testing.T, title, path, icon int) {
+	t.Helper()
+	original := config.AppConfig
+	config.AppConfig = &config.Config{
+		MenuTitleMax: title,
+		MenuPathMax:  path,
+		MenuIconMax:  icon,
+	}
+	t.Cleanup(func() { config.AppConfig = original })
+}
+
+func TestCreateMenuRequest_DefaultLimits(t *testing.T) {
+	path := strings.Repeat("p", 255)
+	icon := strings.Repeat("i", 100)
+	req := dto.CreateMenuRequest{Title: strings.Repeat("t", 255), Path: &path, Icon: &icon}
+
+	testutil.AssertNil(t, req.Validate())
+
+	req.Title += "t"
+	testutil.AssertEqual(t, "title cannot exceed 255 characters", req.Validate().Error())
+}
+
+func TestCreateMenuRequest_OverriddenLimits(t *testing.T) {
+	withLengthLimits(t, 10, 20, 5)
+
+	tests := []struct {
+		name     string
+		req      dto.CreateMenuRequest
+		expected string
+	}{
+		{
+			name:     "title over limit",
+			req:      dto.CreateMenuRequest{Title: strings.Repeat("t", 11)},
+			expected: "title cannot exceed 10 characters",
+		},
+		{
+			name:     "path over limit",
+			req:      dto.CreateMenuRequest{Title: "Menu", Path: stringPtr(strings.Repeat("p", 21))},
+			expected: "path cannot exceed 20 characters",
+		},
+		{
+			name:     "icon over limit",
+			req:      dto.CreateMenuRequest{Title: "Menu", Icon: stringPtr(strings.Repeat("i", 6))},
+			expected: "icon cannot exceed 5 characters",
+		},
+		{
+			name: "at the limits",
+			req: dto.CreateMenuRequest{
+				Title: strings.Repeat("t", 10),
+				Path:  stringPtr(strings.Repeat("p", 20)),
+				Icon:  stringPtr(strings.Repeat("i", 5)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.expected == "" {
+				testutil.AssertNil(t, err)
+				return
+			}
+			testutil.AssertNotNil(t, err)
+			testutil.AssertEqual(t, tt.expected, err.Error())
+		})
+	}
+}
+
+func TestUpdateMenuRequest_OverriddenLimits(t *testing.T) {
+	withLengthLimits(t, 10, 20, 5)
+
+	req := dto.UpdateMenuRequest{Title: stringPtr(strings.Repeat("t", 11))}
+	testutil.AssertEqual(t, "title cannot exceed 10 characters", req.Validate().Error())
+
+	req = dto.UpdateMenuRequest{Icon: stringPtr(strings.Repeat("i", 6))}
+	testutil.AssertEqual(t, "icon cannot exceed 5 characters", req.Validate().Error())
+}
+
+func TestCreateMenuRequest_ReportsAllViolations(t *testing.T) {
+	withLengthLimits(t, 10, 20, 5)
+
+	orderIndex := -1
+	req := dto.CreateMenuRequest{
+		Title:      "  ",
+		Path:       stringPtr(strings.Repeat("p", 21)),
+		Icon:       stringPtr(strings.Repeat("i", 6)),
+		OrderIndex: &orderIndex,
+	}
+
+	err := req.Validate()
+	testutil.AssertNotNil(t, err)
+	testutil.AssertEqual(t, "title is required and cannot be empty; path cannot exceed 20 characters; icon cannot exceed 5 characters; order_index must be a non-negative integer", err.Error())
+
+	fields := dto.FieldErrors(err)
+	testutil.AssertEqual(t, 4, len(fields))
+	testutil.AssertEqual(t, "title is required and cannot be empty", fields["title"])
+	testutil.AssertEqual(t, "path cannot exceed 20 characters", fields["path"])
+	testutil.AssertEqual(t, "icon cannot exceed 5 characters", fields["icon"])
+	testutil.AssertEqual(t, "order_index must be a non-negative integer", fields["order_index"])
+}
+
+func TestUpdateMenuRequest_ReportsAllViolations(t *testing.T) {
+	withLengthLimits(t, 10, 20, 5)
+
+	req := dto.UpdateMenuRequest{
+		Title: stringPtr(strings.Repeat("t", 11)),
+		Icon:  stringPtr(strings.Repeat("i", 6)),
+	}
+
+	fields := dto.FieldErrors(req.Validate())
+	testutil.AssertEqual(t, 2, len(fields))
+	testutil.AssertEqual(t, "title cannot exceed 10 characters", fields["title"])
+	testutil.AssertEqual(t, "icon cannot exceed 5 characters", fields["icon"])
+}
+
+func TestFieldErrors_OtherErrors(t *testing.T) {
+	testutil.AssertNil(t, dto.FieldErrors(errors.New("boom")))
+	testutil.AssertNil(t, dto.FieldErrors(nil))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
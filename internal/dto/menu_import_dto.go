@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/google/uuid"
+)
+
+type ImportMenusRequest struct {
+	models.MenuExport
+}
+
+// Validate checks every node with the same rules as CreateMenuRequest and
+// rejects IDs that appear more than once in the document
+func (r *ImportMenusRequest) Validate() error {
+	seen := make(map[uuid.UUID]bool)
+	return validateImportNodes(r.Menus, seen)
+}
+
+func validateImportNodes(nodes []models.MenuTreeNode, seen map[uuid.UUID]bool) error {
+	for _, node := range nodes {
+		create := CreateMenuRequest{
+			Title: node.Title,
+			Path:  node.Path,
+			Icon:  node.Icon,
+		}
+		if err := create.Validate(); err != nil {
+			return fmt.Errorf("menu %q: %w", node.Title, err)
+		}
+
+		if node.ID != nil {
+			if seen[*node.ID] {
+				return errors.New("menu IDs must be unique within the import")
+			}
+			seen[*node.ID] = true
+		}
+
+		if err := validateImportNodes(node.Children, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ImportMenusResponse struct {
+	Created int `json:"created" example:"4"`
+	Updated int `json:"updated" example:"2"`
+}
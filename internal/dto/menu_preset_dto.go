@@ -0,0 +1,19 @@
+package dto
+
+import "strings"
+
+type CreateMenuPresetRequest struct {
+	Name string `json:"name" example:"Tenant A"`
+}
+
+func (r *CreateMenuPresetRequest) Validate() error {
+	var v ValidationError
+
+	if strings.TrimSpace(r.Name) == "" {
+		v.Add("name", "name is required and cannot be empty")
+	} else if len(r.Name) > 255 {
+		v.Add("name", "name cannot exceed 255 characters")
+	}
+
+	return v.Err()
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+)
+
+// ValidationError collects every failed rule of a request, keyed by the
+// JSON field it concerns. Error joins the messages in the order they were
+// added, so a single failure reads exactly like its message.
+type ValidationError struct {
+	Fields map[string]string
+	order  []string
+}
+
+// Add records message for field; only the first message per field is kept
+func (e *ValidationError) Add(field, message string) {
+	if e.Fields == nil {
+		e.Fields = make(map[string]string)
+	}
+	if _, exists := e.Fields[field]; exists {
+		return
+	}
+	e.Fields[field] = message
+	e.order = append(e.order, field)
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.order))
+	for i, field := range e.order {
+		messages[i] = e.Fields[field]
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Err returns e, or nil when no rule failed
+func (e *ValidationError) Err() error {
+	if len(e.order) == 0 {
+		return nil
+	}
+	return e
+}
+
+// FieldErrors returns the field-to-message map of a validation error, or nil
+// for any other error
+func FieldErrors(err error) map[string]string {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Fields
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"github.com/andhikadk/stk-test-be/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// currentUserID returns the authenticated user stored in the request context
+// by the auth middleware, or nil when the route is public
+func currentUserID(c *fiber.Ctx) *uint {
+	switch id := c.Locals("user_id").(type) {
+	case uint:
+		return &id
+	case int:
+		if id > 0 {
+			userID := uint(id)
+			return &userID
+		}
+	}
+	return nil
+}
+
+// requestDB returns the database bound to the request context, so queries
+// are cancelled when the request times out
+func requestDB(c *fiber.Ctx) *gorm.DB {
+	return database.GetDB().WithContext(c.UserContext())
+}
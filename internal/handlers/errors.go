@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/services"
+)
+
+// serviceErrorCodes maps service sentinel errors to their response codes
+var serviceErrorCodes = []struct {
+	err  error
+	code string
+}{
+	{services.ErrMenuNotFound, models.CodeMenuNotFound},
+	{services.ErrParentMenuNotFound, models.CodeParentMenuNotFound},
+	{services.ErrParentMenuDeleted, models.CodeParentMenuDeleted},
+	{services.ErrDeletedMenuNotFound, models.CodeDeletedMenuNotFound},
+	{services.ErrMenuDepthExceeded, models.CodeMenuDepthExceeded},
+	{services.ErrMenuCycle, models.CodeMenuCycle},
+	{services.ErrMenuPathTaken, models.CodeMenuPathTaken},
+	{services.ErrSiblingSetMismatch, models.CodeSiblingSetMismatch},
+	{services.ErrOldIndexOutOfRange, models.CodeOldIndexOutOfRange},
+	{services.ErrStaleOldIndex, models.CodeStaleOldIndex},
+	{services.ErrInvalidImportMode, models.CodeInvalidImportMode},
+	{services.ErrPresetNotFound, models.CodeMenuPresetNotFound},
+	{services.ErrPresetNameTaken, models.CodeMenuPresetNameTaken},
+}
+
+// errorCode returns the response code for an error returned by a service,
+// falling back to INTERNAL_ERROR for anything unexpected
+func errorCode(err error) string {
+	for _, known := range serviceErrorCodes {
+		if errors.Is(err, known.err) {
+			return known.code
+		}
+	}
+	return models.CodeInternalError
+}
@@ -1,25 +1,103 @@
 package handlers
 
 import (
+	"context"
+	"time"
+
 	"github.com/andhikadk/stk-test-be/config"
-	"github.com/andhikadk/stk-test-be/pkg/utils"
+	"github.com/andhikadk/stk-test-be/internal/database"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/utils"
+	pkgutils "github.com/andhikadk/stk-test-be/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // HealthCheck godoc
 // @Summary      Health Check
-// @Description  Check API health status
+// @Description  Check API health status and report the running build
 // @Tags         Health
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  models.APIResponse{data=models.HealthStatus}
 // @Router       /health [get]
 func HealthCheck(c *fiber.Ctx) error {
-	return utils.SuccessResponse(c, fiber.StatusOK, "API is running", fiber.Map{
-		"app":     config.AppConfig.AppName,
-		"status":  "healthy",
-		"version": "1.0.0",
-		"env":     config.AppConfig.Env,
+	return pkgutils.SuccessResponse(c, fiber.StatusOK, "API is running", models.HealthStatus{
+		App:       config.AppConfig.AppName,
+		Status:    "healthy",
+		Env:       config.AppConfig.Env,
+		BuildInfo: buildInfo(),
 	})
 }
+
+// VersionInfo godoc
+// @Summary      Build Version
+// @Description  Report the version, git commit and build time of the running binary
+// @Tags         Health
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.APIResponse{data=models.BuildInfo}
+// @Router       /version [get]
+func VersionInfo(c *fiber.Ctx) error {
+	return pkgutils.SuccessResponse(c, fiber.StatusOK, "Build version", buildInfo())
+}
+
+func buildInfo() models.BuildInfo {
+	return models.BuildInfo{
+		Version:   config.Version,
+		Commit:    config.Commit,
+		BuildTime: config.BuildTime,
+	}
+}
+
+// readinessPingTimeout bounds how long the readiness check waits for the
+// database to answer
+const readinessPingTimeout = 2 * time.Second
+
+// ReadinessCheck godoc
+// @Summary      Readiness Check
+// @Description  Check whether the API can serve traffic by pinging its dependencies; responds 503 while any of them is down. Unlike /health, this touches the database.
+// @Tags         Health
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.APIResponse{data=models.ReadinessStatus}
+// @Failure      503  {object}  models.APIResponse{data=models.ReadinessStatus}
+// @Router       /ready [get]
+func ReadinessCheck(c *fiber.Ctx) error {
+	sqlDB, err := database.GetDB().DB()
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to get database pool", "handler", "ReadinessCheck", "error", err)
+		return pkgutils.ErrorResponse(c, fiber.StatusServiceUnavailable, "database unavailable")
+	}
+
+	status := models.ReadinessStatus{Database: "up"}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), readinessPingTimeout)
+	defer cancel()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		utils.Error(c.UserContext(), "database ping failed", "handler", "ReadinessCheck", "error", err)
+		status.Database = "down"
+	}
+
+	stats := sqlDB.Stats()
+	status.Pool = models.DBPoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration.String(),
+	}
+
+	if status.Database != "up" {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(models.APIResponse{
+			Status:  fiber.StatusServiceUnavailable,
+			Code:    models.CodeServiceUnavailable,
+			Message: "API is not ready",
+			Data:    status,
+			Error:   "database is down",
+		})
+	}
+
+	return pkgutils.SuccessResponse(c, fiber.StatusOK, "API is ready", status)
+}
@@ -0,0 +1,130 @@
+package handlers_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/config"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestHealthCheck_ReportsBuildInfo(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	originalConfig := config.AppConfig
+	config.AppConfig = &config.Config{AppName: "stk-test-be", Env: "test"}
+	defer func() {
+		config.AppConfig = originalConfig
+	}()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/health", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result struct {
+		Status int                    `json:"status"`
+		Data   map[string]interface{} `json:"data"`
+	}
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, "healthy", result.Data["status"])
+	testutil.AssertEqual(t, config.Version, result.Data["version"])
+	testutil.AssertEqual(t, config.Commit, result.Data["commit"])
+	testutil.AssertEqual(t, config.BuildTime, result.Data["build_time"])
+}
+
+func TestVersionInfo(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/version", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result struct {
+		Status int              `json:"status"`
+		Data   models.BuildInfo `json:"data"`
+	}
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, models.BuildInfo{Version: "dev", Commit: "dev", BuildTime: "dev"}, result.Data)
+}
+
+func TestReadinessCheck_ReportsPoolStats(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(7)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/ready", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result struct {
+		Status int                    `json:"status"`
+		Data   models.ReadinessStatus `json:"data"`
+	}
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, 7, result.Data.Pool.MaxOpenConnections)
+	testutil.AssertNotEmpty(t, result.Data.Pool.WaitDuration)
+}
+
+func TestReadinessCheck_DatabaseUp(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/ready", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result struct {
+		Data models.ReadinessStatus `json:"data"`
+	}
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, "up", result.Data.Database)
+}
+
+func TestReadinessCheck_ClosedConnectionIsNotReady(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	sqlDB, _ := db.DB()
+	sqlDB.Close()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/ready", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusServiceUnavailable, resp)
+
+	var result struct {
+		Status int                    `json:"status"`
+		Code   string                 `json:"code"`
+		Data   models.ReadinessStatus `json:"data"`
+	}
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, fiber.StatusServiceUnavailable, result.Status)
+	testutil.AssertEqual(t, models.CodeServiceUnavailable, result.Code)
+	testutil.AssertEqual(t, "down", result.Data.Database)
+}
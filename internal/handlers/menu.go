@@ -1,11 +1,15 @@
 package handlers
 
 import (
-	"github.com/andhikadk/stk-test-be/internal/database"
+	"errors"
+	"fmt"
+
 	"github.com/andhikadk/stk-test-be/internal/dto"
 	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/query"
 	"github.com/andhikadk/stk-test-be/internal/services"
 	"github.com/andhikadk/stk-test-be/internal/utils"
+	pkgutils "github.com/andhikadk/stk-test-be/pkg/utils"
 	"github.com/google/uuid"
 
 	"github.com/gofiber/fiber/v2"
@@ -17,17 +21,23 @@ import (
 // @Tags         Menus
 // @Accept       json
 // @Produce      json
+// @Param        include_deleted  query     bool  false  "Include soft-deleted menus"
+// @Param        active_only      query     bool  false  "Leave out inactive menus together with their descendants"
 // @Success      200  {object}  models.APIResponse{data=[]models.Menu}
 // @Failure      500  {object}  models.APIResponse
 // @Router       /api/menus [get]
 func GetMenus(c *fiber.Ctx) error {
-	menuService := services.NewMenuService(database.GetDB())
-	menus, err := menuService.GetMenuTree()
+	menuService := services.NewMenuService(requestDB(c))
+	menus, err := menuService.GetMenuTree(services.TreeOptions{
+		IncludeDeleted: c.QueryBool("include_deleted"),
+		ActiveOnly:     c.QueryBool("active_only"),
+	})
 	if err != nil {
-		utils.ErrorLogger.Printf("[GetMenus] Failed to fetch menu tree: %v", err)
+		utils.Error(c.UserContext(), "failed to fetch menu tree", "handler", "GetMenus", "error", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
 			Status:  fiber.StatusInternalServerError,
 			Message: "Failed to fetch menus",
+			Code:    errorCode(err),
 			Error:   err.Error(),
 		})
 	}
@@ -39,16 +49,166 @@ func GetMenus(c *fiber.Ctx) error {
 	})
 }
 
+// ListMenus godoc
+// @Summary      List menu items
+// @Description  Get menu items as a flat, paginated list with optional search and created_at range
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        page          query     int     false  "Page number"  default(1)
+// @Param        limit         query     int     false  "Items per page"  default(10)  maximum(100)
+// @Param        sort          query     string  false  "Sort column"  Enums(order_index, title, created_at, updated_at)
+// @Param        order         query     string  false  "Sort direction"  Enums(asc, desc)
+// @Param        search        query     string  false  "Case-insensitive match on title or path"
+// @Param        created_from  query     string  false  "Created on or after (YYYY-MM-DD or RFC 3339)"
+// @Param        created_to    query     string  false  "Created on or before (YYYY-MM-DD or RFC 3339)"
+// @Success      200           {object}  models.PaginatedResponse{data=[]models.Menu}
+// @Failure      400           {object}  models.APIResponse
+// @Failure      500           {object}  models.APIResponse
+// @Router       /api/menus/list [get]
+func ListMenus(c *fiber.Ctx) error {
+	params, err := query.ParseListParams(c, services.MenuListSorts)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid query parameters",
+			Code:    models.CodeInvalidQuery,
+			Error:   err.Error(),
+		})
+	}
+
+	menuService := services.NewMenuService(requestDB(c))
+	menus, total, err := menuService.ListMenus(params)
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to list menus", "handler", "ListMenus", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to list menus",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return pkgutils.PaginatedResponse(c, "Menus retrieved successfully", menus, params.Page, params.Limit, total)
+}
+
+// GetMenuOrphans godoc
+// @Summary      List orphaned menu items
+// @Description  Diagnostics: list menu items whose parent_id points to a menu that no longer exists
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Success      200            {object}  models.APIResponse{data=[]models.Menu}
+// @Failure      401            {object}  models.APIResponse
+// @Failure      403            {object}  models.APIResponse
+// @Failure      500            {object}  models.APIResponse
+// @Router       /api/menus/orphans [get]
+func GetMenuOrphans(c *fiber.Ctx) error {
+	menuService := services.NewMenuService(requestDB(c))
+	orphans, err := menuService.GetOrphans()
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to fetch orphaned menus", "handler", "GetMenuOrphans", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to fetch orphaned menus",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Orphaned menus retrieved successfully",
+		Data:    orphans,
+	})
+}
+
+// ValidateMenuTree godoc
+// @Summary      Validate menu tree
+// @Description  Read-only health check of the whole tree: cycles, orphans, gaps in sibling order indexes, duplicate paths and depth beyond the limit
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Success      200            {object}  models.APIResponse{data=models.MenuTreeReport}
+// @Failure      401            {object}  models.APIResponse
+// @Failure      403            {object}  models.APIResponse
+// @Failure      500            {object}  models.APIResponse
+// @Router       /api/menus/validate [get]
+func ValidateMenuTree(c *fiber.Ctx) error {
+	menuService := services.NewMenuService(requestDB(c))
+	report, err := menuService.ValidateTree()
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to validate menu tree", "handler", "ValidateMenuTree", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to validate menu tree",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Menu tree validated",
+		Data:    report,
+	})
+}
+
+// GetMenuSelectOptions godoc
+// @Summary      Get menu select options
+// @Description  Get the menu tree flattened in pre-order with the depth of each item, for indented select inputs
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        max_depth  query     int  false  "Number of levels to include; 0 or omitted includes all"  minimum(0)
+// @Success      200        {object}  models.APIResponse{data=[]models.MenuSelectOption}
+// @Failure      400        {object}  models.APIResponse
+// @Failure      500        {object}  models.APIResponse
+// @Router       /api/menus/select-options [get]
+func GetMenuSelectOptions(c *fiber.Ctx) error {
+	maxDepth := c.QueryInt("max_depth", 0)
+	if maxDepth < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Validation failed",
+			Code:    models.CodeValidationFailed,
+			Error:   "max_depth must be a non-negative integer",
+		})
+	}
+
+	menuService := services.NewMenuService(requestDB(c))
+	options, err := menuService.GetSelectOptions(maxDepth)
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to fetch select options", "handler", "GetMenuSelectOptions", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to fetch menu select options",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Menu select options retrieved successfully",
+		Data:    options,
+	})
+}
+
 // GetMenu godoc
 // @Summary      Get single menu item
-// @Description  Get a single menu item by ID
+// @Description  Get a single menu item by ID with its subtree, including its child and descendant counts
 // @Tags         Menus
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Menu ID (UUID format)"
-// @Success      200  {object}  models.APIResponse{data=models.Menu}
-// @Failure      400  {object}  models.APIResponse
-// @Failure      404  {object}  models.APIResponse
+// @Param        id     path      string  true   "Menu ID (UUID format)"  Format(uuid)
+// @Param        depth  query     int     false  "Number of levels of children to include; 0 or omitted includes the whole subtree"  minimum(0)
+// @Success      200    {object}  models.APIResponse{data=dto.MenuDetailResponse}
+// @Failure      400    {object}  models.APIResponse
+// @Failure      404    {object}  models.APIResponse
+// @Failure      500    {object}  models.APIResponse
 // @Router       /api/menus/{id} [get]
 func GetMenu(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
@@ -56,17 +216,40 @@ func GetMenu(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
 			Message: "Invalid menu ID",
+			Code:    models.CodeInvalidID,
 			Error:   err.Error(),
 		})
 	}
 
-	menuService := services.NewMenuService(database.GetDB())
-	menu, err := menuService.GetMenuByID(id)
+	depth := c.QueryInt("depth", 0)
+	if depth < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Validation failed",
+			Code:    models.CodeValidationFailed,
+			Error:   "depth must be a non-negative integer",
+		})
+	}
+
+	menuService := services.NewMenuService(requestDB(c))
+	menu, err := menuService.GetMenuSubtree(id, depth)
 	if err != nil {
-		utils.ErrorLogger.Printf("[GetMenu] menuID=%s error: %v", id, err)
+		utils.Error(c.UserContext(), "request failed", "handler", "GetMenu", "menu_id", id, "error", err)
 		return c.Status(fiber.StatusNotFound).JSON(models.APIResponse{
 			Status:  fiber.StatusNotFound,
 			Message: "Menu not found",
+			Code:    models.CodeMenuNotFound,
+			Error:   err.Error(),
+		})
+	}
+
+	childCount, descendantCount, err := menuService.CountDescendants(id)
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to count descendants", "handler", "GetMenu", "menu_id", id, "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to fetch menu",
+			Code:    errorCode(err),
 			Error:   err.Error(),
 		})
 	}
@@ -74,39 +257,35 @@ func GetMenu(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
 		Status:  fiber.StatusOK,
 		Message: "Menu retrieved successfully",
-		Data:    menu,
+		Data: dto.MenuDetailResponse{
+			Menu:            *menu,
+			ChildCount:      childCount,
+			DescendantCount: descendantCount,
+		},
 	})
 }
 
 // CreateMenu godoc
 // @Summary      Create new menu item
-// @Description  Create a new menu item
+// @Description  Create a new menu item. With upsert=true a live menu with the same path is updated instead, changing its title and the optional fields sent, and 200 is returned
 // @Tags         Menus
 // @Accept       json
 // @Produce      json
-// @Param        menu  body      dto.CreateMenuRequest  true  "Menu creation data"
-// @Success      201   {object}  models.APIResponse{data=models.Menu}
-// @Failure      400   {object}  models.APIResponse
-// @Failure      500   {object}  models.APIResponse
+// @Param        menu             body      dto.CreateMenuRequest  true   "Menu creation data"
+// @Param        upsert           query     bool                   false  "Update the menu with the same path if there is one"
+// @Param        Idempotency-Key  header    string                 false  "Makes retries safe: the first response for the key is replayed for repeats with the same body"
+// @Success      200              {object}  models.APIResponse{data=models.Menu}
+// @Success      201              {object}  models.APIResponse{data=models.Menu}
+// @Header       201              {string}  Location  "URL of the created menu"
+// @Failure      400              {object}  models.APIResponse
+// @Failure      409              {object}  models.APIResponse
+// @Failure      422              {object}  models.APIResponse
+// @Failure      500              {object}  models.APIResponse
 // @Router       /api/menus [post]
 func CreateMenu(c *fiber.Ctx) error {
-	var req dto.CreateMenuRequest
-
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
-			Status:  fiber.StatusBadRequest,
-			Message: "Invalid request body",
-			Error:   err.Error(),
-		})
-	}
-
-	if err := req.Validate(); err != nil {
-		utils.ErrorLogger.Printf("[CreateMenu] Validation failed: %v", err)
-		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
-			Status:  fiber.StatusBadRequest,
-			Message: "Validation failed",
-			Error:   err.Error(),
-		})
+	req, err := utils.BindAndValidate[dto.CreateMenuRequest](c)
+	if err != nil {
+		return err
 	}
 
 	menu := models.Menu{
@@ -115,39 +294,143 @@ func CreateMenu(c *fiber.Ctx) error {
 		Path:       req.Path,
 		Icon:       req.Icon,
 		OrderIndex: 0,
+		IsActive:   true,
 	}
 
 	if req.OrderIndex != nil {
 		menu.OrderIndex = *req.OrderIndex
 	}
+	if req.IsActive != nil {
+		menu.IsActive = *req.IsActive
+	}
 
-	menuService := services.NewMenuService(database.GetDB())
-	if err := menuService.CreateMenu(&menu); err != nil {
-		utils.ErrorLogger.Printf("[CreateMenu] Failed to create menu '%s': %v", req.Title, err)
+	menuService := services.NewMenuService(requestDB(c)).WithActor(currentUserID(c))
+	created := true
+	if c.QueryBool("upsert") {
+		created, err = menuService.UpsertMenu(&menu, req.UpsertColumns())
+	} else {
+		err = menuService.CreateMenu(&menu)
+	}
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to create menu", "handler", "CreateMenu", "title", req.Title, "error", err)
+		if errors.Is(err, services.ErrMenuPathTaken) {
+			return c.Status(fiber.StatusConflict).JSON(models.APIResponse{
+				Status:  fiber.StatusConflict,
+				Message: "Failed to create menu",
+				Code:    errorCode(err),
+				Error:   err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrMenuDepthExceeded) || errors.Is(err, services.ErrParentMenuNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Failed to create menu",
+				Code:    errorCode(err),
+				Error:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
 			Status:  fiber.StatusInternalServerError,
 			Message: "Failed to create menu",
+			Code:    errorCode(err),
 			Error:   err.Error(),
 		})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(models.APIResponse{
-		Status:  fiber.StatusCreated,
-		Message: "Menu created successfully",
-		Data:    menu,
-	})
+	if !created {
+		updated, _ := menuService.GetMenuByID(menu.ID)
+		return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+			Status:  fiber.StatusOK,
+			Message: "Menu updated successfully",
+			Data:    updated,
+		})
+	}
+
+	return pkgutils.CreatedResponseWithLocation(c, menuLocation(menu.ID), "Menu created successfully", menu)
+}
+
+// BulkCreateMenus godoc
+// @Summary      Create several menu items
+// @Description  Create the menus in array order in one transaction: either all are created or none is. Items without order_index are appended to their sibling group; parent_index places an item under an earlier item of the same batch. A failing item is reported by its array position.
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        menus  body      dto.BulkCreateMenusRequest  true  "Menus to create"
+// @Success      201    {object}  models.APIResponse{data=[]models.Menu}
+// @Failure      400    {object}  models.APIResponse
+// @Failure      409    {object}  models.APIResponse
+// @Failure      500    {object}  models.APIResponse
+// @Router       /api/menus/bulk [post]
+func BulkCreateMenus(c *fiber.Ctx) error {
+	req, err := utils.BindAndValidate[dto.BulkCreateMenusRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// IDs are assigned up front so that parent_index can be resolved before
+	// the parent is inserted
+	menus := make([]*models.Menu, len(req))
+	for i, item := range req {
+		menu := &models.Menu{
+			ID:         uuid.New(),
+			ParentID:   item.ParentID,
+			Title:      item.Title,
+			Path:       item.Path,
+			Icon:       item.Icon,
+			OrderIndex: services.AppendOrderIndex,
+			IsActive:   true,
+		}
+		if item.ParentIndex != nil {
+			menu.ParentID = &menus[*item.ParentIndex].ID
+		}
+		if item.OrderIndex != nil {
+			menu.OrderIndex = *item.OrderIndex
+		}
+		if item.IsActive != nil {
+			menu.IsActive = *item.IsActive
+		}
+		menus[i] = menu
+	}
+
+	menuService := services.NewMenuService(requestDB(c)).WithActor(currentUserID(c))
+	if err := menuService.CreateMenus(menus); err != nil {
+		utils.Error(c.UserContext(), "failed to create menus", "handler", "BulkCreateMenus", "count", len(menus), "error", err)
+		status := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrMenuPathTaken):
+			status = fiber.StatusConflict
+		case errors.Is(err, services.ErrMenuDepthExceeded), errors.Is(err, services.ErrParentMenuNotFound):
+			status = fiber.StatusBadRequest
+		}
+
+		response := models.APIResponse{
+			Status:  status,
+			Message: "Failed to create menus",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		}
+		var itemErr *services.BatchItemError
+		if errors.As(err, &itemErr) {
+			response.Errors = map[string]string{fmt.Sprintf("[%d]", itemErr.Index): itemErr.Err.Error()}
+		}
+		return c.Status(status).JSON(response)
+	}
+
+	return pkgutils.CreatedResponse(c, "Menus created successfully", menus)
 }
 
 // UpdateMenu godoc
 // @Summary      Update menu item
-// @Description  Update a menu item
+// @Description  Update a menu item. Only fields present in the body are changed; send null to clear path, icon or parent_id. A new parent_id appends the menu to its new sibling group, or places it at order_index when that is sent too. A new parent is checked like in the move endpoint
 // @Tags         Menus
 // @Accept       json
 // @Produce      json
-// @Param        id    path      string                 true  "Menu ID (UUID format)"
+// @Param        id    path      string                 true  "Menu ID (UUID format)"  Format(uuid)
 // @Param        menu  body      dto.UpdateMenuRequest  true  "Menu update data"
 // @Success      200   {object}  models.APIResponse{data=models.Menu}
 // @Failure      400   {object}  models.APIResponse
+// @Failure      404   {object}  models.APIResponse
+// @Failure      409   {object}  models.APIResponse
 // @Failure      500   {object}  models.APIResponse
 // @Router       /api/menus/{id} [put]
 func UpdateMenu(c *fiber.Ctx) error {
@@ -156,51 +439,62 @@ func UpdateMenu(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
 			Message: "Invalid menu ID",
+			Code:    models.CodeInvalidID,
 			Error:   err.Error(),
 		})
 	}
 
-	var req dto.UpdateMenuRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
-			Status:  fiber.StatusBadRequest,
-			Message: "Invalid request body",
-			Error:   err.Error(),
-		})
-	}
-
-	if err := req.Validate(); err != nil {
-		utils.ErrorLogger.Printf("[UpdateMenu] menuID=%s validation failed: %v", id, err)
-		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
-			Status:  fiber.StatusBadRequest,
-			Message: "Validation failed",
-			Error:   err.Error(),
-		})
+	req, err := utils.BindAndValidate[dto.UpdateMenuRequest](c)
+	if err != nil {
+		return err
 	}
 
-	menu := models.Menu{}
-	if req.ParentID != nil {
-		menu.ParentID = req.ParentID
+	menu := models.Menu{
+		ParentID: req.ParentID,
+		Path:     req.Path,
+		Icon:     req.Icon,
 	}
 	if req.Title != nil {
 		menu.Title = *req.Title
 	}
-	if req.Path != nil {
-		menu.Path = req.Path
-	}
-	if req.Icon != nil {
-		menu.Icon = req.Icon
-	}
 	if req.OrderIndex != nil {
 		menu.OrderIndex = *req.OrderIndex
 	}
+	if req.IsActive != nil {
+		menu.IsActive = *req.IsActive
+	}
 
-	menuService := services.NewMenuService(database.GetDB())
-	if err := menuService.UpdateMenu(id, &menu); err != nil {
-		utils.ErrorLogger.Printf("[UpdateMenu] menuID=%s error: %v", id, err)
+	menuService := services.NewMenuService(requestDB(c)).WithActor(currentUserID(c))
+	if err := menuService.UpdateMenu(id, &menu, req.ProvidedColumns()); err != nil {
+		utils.Error(c.UserContext(), "request failed", "handler", "UpdateMenu", "menu_id", id, "error", err)
+		if errors.Is(err, services.ErrMenuNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(models.APIResponse{
+				Status:  fiber.StatusNotFound,
+				Message: "Failed to update menu",
+				Code:    errorCode(err),
+				Error:   err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrParentMenuNotFound) || errors.Is(err, services.ErrMenuDepthExceeded) || errors.Is(err, services.ErrMenuCycle) {
+			return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Failed to update menu",
+				Code:    errorCode(err),
+				Error:   err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrMenuPathTaken) {
+			return c.Status(fiber.StatusConflict).JSON(models.APIResponse{
+				Status:  fiber.StatusConflict,
+				Message: "Failed to update menu",
+				Code:    errorCode(err),
+				Error:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
 			Status:  fiber.StatusInternalServerError,
 			Message: "Failed to update menu",
+			Code:    errorCode(err),
 			Error:   err.Error(),
 		})
 	}
@@ -215,11 +509,11 @@ func UpdateMenu(c *fiber.Ctx) error {
 
 // DeleteMenu godoc
 // @Summary      Delete menu item
-// @Description  Delete a menu item and its children
+// @Description  Soft-delete a menu item and all of its descendants
 // @Tags         Menus
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Menu ID (UUID format)"
+// @Param        id   path      string  true  "Menu ID (UUID format)"  Format(uuid)
 // @Success      200  {object}  models.APIResponse
 // @Failure      400  {object}  models.APIResponse
 // @Failure      500  {object}  models.APIResponse
@@ -230,16 +524,18 @@ func DeleteMenu(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
 			Message: "Invalid menu ID",
+			Code:    models.CodeInvalidID,
 			Error:   err.Error(),
 		})
 	}
 
-	menuService := services.NewMenuService(database.GetDB())
+	menuService := services.NewMenuService(requestDB(c))
 	if err := menuService.DeleteMenu(id); err != nil {
-		utils.ErrorLogger.Printf("[DeleteMenu] menuID=%s error: %v", id, err)
+		utils.Error(c.UserContext(), "request failed", "handler", "DeleteMenu", "menu_id", id, "error", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
 			Status:  fiber.StatusInternalServerError,
 			Message: "Failed to delete menu",
+			Code:    errorCode(err),
 			Error:   err.Error(),
 		})
 	}
@@ -250,52 +546,138 @@ func DeleteMenu(c *fiber.Ctx) error {
 	})
 }
 
-// MoveMenu godoc
-// @Summary      Move menu item to different parent
-// @Description  Move a menu item to a different parent
+// RestoreMenu godoc
+// @Summary      Restore deleted menu item
+// @Description  Restore a soft-deleted menu item together with its deleted descendants
 // @Tags         Menus
 // @Accept       json
 // @Produce      json
-// @Param        id       path      string               true  "Menu ID (UUID format)"
-// @Param        request  body      dto.MoveMenuRequest  true  "Move request"
-// @Success      200      {object}  models.APIResponse{data=models.Menu}
-// @Failure      400      {object}  models.APIResponse
-// @Router       /api/menus/{id}/move [patch]
-func MoveMenu(c *fiber.Ctx) error {
+// @Param        id   path      string  true  "Menu ID (UUID format)"  Format(uuid)
+// @Success      200  {object}  models.APIResponse{data=models.Menu}
+// @Failure      400  {object}  models.APIResponse
+// @Failure      404  {object}  models.APIResponse
+// @Failure      409  {object}  models.APIResponse
+// @Failure      500  {object}  models.APIResponse
+// @Router       /api/menus/{id}/restore [post]
+func RestoreMenu(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
 			Message: "Invalid menu ID",
+			Code:    models.CodeInvalidID,
 			Error:   err.Error(),
 		})
 	}
 
-	var req dto.MoveMenuRequest
+	menuService := services.NewMenuService(requestDB(c))
+	if err := menuService.RestoreMenu(id); err != nil {
+		utils.Error(c.UserContext(), "request failed", "handler", "RestoreMenu", "menu_id", id, "error", err)
+		status := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrDeletedMenuNotFound):
+			status = fiber.StatusNotFound
+		case errors.Is(err, services.ErrParentMenuDeleted):
+			status = fiber.StatusBadRequest
+		case errors.Is(err, services.ErrMenuPathTaken):
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(models.APIResponse{
+			Status:  status,
+			Message: "Failed to restore menu",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
 
-	if err := c.BodyParser(&req); err != nil {
+	restored, _ := menuService.GetMenuByID(id)
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Menu restored successfully",
+		Data:    restored,
+	})
+}
+
+// ToggleMenu godoc
+// @Summary      Toggle menu visibility
+// @Description  Flip the is_active flag of a menu item. Inactive menus and their descendants are left out of active-only trees.
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Menu ID (UUID format)"  Format(uuid)
+// @Success      200  {object}  models.APIResponse{data=models.Menu}
+// @Failure      400  {object}  models.APIResponse
+// @Failure      404  {object}  models.APIResponse
+// @Failure      500  {object}  models.APIResponse
+// @Router       /api/menus/{id}/toggle [patch]
+func ToggleMenu(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
-			Message: "Invalid request body",
+			Message: "Invalid menu ID",
+			Code:    models.CodeInvalidID,
 			Error:   err.Error(),
 		})
 	}
 
-	if err := req.Validate(); err != nil {
-		utils.ErrorLogger.Printf("[MoveMenu] menuID=%s validation failed: %v", id, err)
+	menuService := services.NewMenuService(requestDB(c))
+	if err := menuService.ToggleMenu(id); err != nil {
+		utils.Error(c.UserContext(), "request failed", "handler", "ToggleMenu", "menu_id", id, "error", err)
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrMenuNotFound) {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(models.APIResponse{
+			Status:  status,
+			Message: "Failed to toggle menu",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	updated, _ := menuService.GetMenuByID(id)
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Menu toggled successfully",
+		Data:    updated,
+	})
+}
+
+// MoveMenu godoc
+// @Summary      Move menu item to different parent
+// @Description  Move a menu item to a different parent. The parent cannot be the menu itself or one of its descendants, and the moved subtree must stay within the depth limit
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string               true  "Menu ID (UUID format)"  Format(uuid)
+// @Param        request  body      dto.MoveMenuRequest  true  "Move request"
+// @Success      200      {object}  models.APIResponse{data=models.Menu}
+// @Failure      400      {object}  models.APIResponse
+// @Router       /api/menus/{id}/move [patch]
+func MoveMenu(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
-			Message: "Validation failed",
+			Message: "Invalid menu ID",
+			Code:    models.CodeInvalidID,
 			Error:   err.Error(),
 		})
 	}
 
-	menuService := services.NewMenuService(database.GetDB())
+	req, err := utils.BindAndValidate[dto.MoveMenuRequest](c)
+	if err != nil {
+		return err
+	}
+
+	menuService := services.NewMenuService(requestDB(c)).WithActor(currentUserID(c))
 	if err := menuService.MoveMenu(id, req.ParentID); err != nil {
-		utils.ErrorLogger.Printf("[MoveMenu] menuID=%s error: %v", id, err)
+		utils.Error(c.UserContext(), "request failed", "handler", "MoveMenu", "menu_id", id, "error", err)
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
 			Message: "Failed to move menu",
+			Code:    errorCode(err),
 			Error:   err.Error(),
 		})
 	}
@@ -308,53 +690,143 @@ func MoveMenu(c *fiber.Ctx) error {
 	})
 }
 
-// ReorderMenu godoc
-// @Summary      Reorder menu item within same level
-// @Description  Change the order index of a menu item
+// CloneMenu godoc
+// @Summary      Clone menu subtree
+// @Description  Deep-copy a menu item and all of its descendants. The copy is appended under parent_id, or under the original's parent when omitted.
 // @Tags         Menus
 // @Accept       json
 // @Produce      json
-// @Param        id       path      string                  true  "Menu ID (UUID format)"
-// @Param        request  body      dto.ReorderMenuRequest  true  "Reorder request"
-// @Success      200      {object}  models.APIResponse{data=models.Menu}
+// @Param        id       path      string                true   "Menu ID (UUID format)"  Format(uuid)
+// @Param        request  body      dto.CloneMenuRequest  false  "Clone request"
+// @Success      201      {object}  models.APIResponse{data=models.Menu}
+// @Header       201      {string}  Location  "URL of the copied subtree's root"
 // @Failure      400      {object}  models.APIResponse
+// @Failure      404      {object}  models.APIResponse
 // @Failure      500      {object}  models.APIResponse
-// @Router       /api/menus/{id}/reorder [patch]
-func ReorderMenu(c *fiber.Ctx) error {
+// @Router       /api/menus/{id}/clone [post]
+func CloneMenu(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
 			Message: "Invalid menu ID",
+			Code:    models.CodeInvalidID,
 			Error:   err.Error(),
 		})
 	}
 
-	var req dto.ReorderMenuRequest
+	var req dto.CloneMenuRequest
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid request body",
+				Code:    models.CodeInvalidRequestBody,
+				Error:   err.Error(),
+			})
+		}
+	}
 
-	if err := c.BodyParser(&req); err != nil {
+	if err := req.Validate(); err != nil {
+		utils.Warn(c.UserContext(), "validation failed", "handler", "CloneMenu", "menu_id", id, "error", err)
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
-			Message: "Invalid request body",
+			Message: "Validation failed",
+			Code:    models.CodeValidationFailed,
 			Error:   err.Error(),
+			Errors:  dto.FieldErrors(err),
 		})
 	}
 
-	if err := req.Validate(); err != nil {
-		utils.ErrorLogger.Printf("[ReorderMenu] menuID=%s validation failed: %v", id, err)
+	menuService := services.NewMenuService(requestDB(c)).WithActor(currentUserID(c))
+	clone, err := menuService.CloneSubtree(id, req.ParentID)
+	if err != nil {
+		utils.Error(c.UserContext(), "request failed", "handler", "CloneMenu", "menu_id", id, "error", err)
+		status := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrMenuNotFound):
+			status = fiber.StatusNotFound
+		case errors.Is(err, services.ErrParentMenuNotFound), errors.Is(err, services.ErrMenuDepthExceeded):
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(models.APIResponse{
+			Status:  status,
+			Message: "Failed to clone menu",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return pkgutils.CreatedResponseWithLocation(c, menuLocation(clone.ID), "Menu cloned successfully", clone)
+}
+
+// menuLocation returns the URL of a single menu, for Location headers
+func menuLocation(id uuid.UUID) string {
+	return "/api/menus/" + id.String()
+}
+
+// ReorderMenu godoc
+// @Summary      Reorder menu item within same level
+// @Description  Change the order index of a menu item. An old_index that no longer matches the menu's position, e.g. after a concurrent reorder, is rejected with 409
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Menu ID (UUID format)"  Format(uuid)
+// @Param        request  body      dto.ReorderMenuRequest  true  "Reorder request"
+// @Success      200      {object}  models.APIResponse{data=models.Menu}
+// @Failure      400      {object}  models.APIResponse
+// @Failure      404      {object}  models.APIResponse
+// @Failure      409      {object}  models.APIResponse
+// @Failure      500      {object}  models.APIResponse
+// @Router       /api/menus/{id}/reorder [patch]
+func ReorderMenu(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
 			Status:  fiber.StatusBadRequest,
-			Message: "Validation failed",
+			Message: "Invalid menu ID",
+			Code:    models.CodeInvalidID,
 			Error:   err.Error(),
 		})
 	}
 
-	menuService := services.NewMenuService(database.GetDB())
+	req, err := utils.BindAndValidate[dto.ReorderMenuRequest](c)
+	if err != nil {
+		return err
+	}
+
+	menuService := services.NewMenuService(requestDB(c)).WithActor(currentUserID(c))
 	if err := menuService.ReorderMenu(id, req.NewIndex, req.OldIndex); err != nil {
-		utils.ErrorLogger.Printf("[ReorderMenu] menuID=%s newIndex=%d error: %v", id, req.NewIndex, err)
+		utils.Error(c.UserContext(), "request failed", "handler", "ReorderMenu", "menu_id", id, "new_index", req.NewIndex, "error", err)
+		if errors.Is(err, services.ErrMenuNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(models.APIResponse{
+				Status:  fiber.StatusNotFound,
+				Message: "Failed to reorder menu",
+				Code:    errorCode(err),
+				Error:   err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrOldIndexOutOfRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Failed to reorder menu",
+				Code:    errorCode(err),
+				Error:   err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrStaleOldIndex) {
+			return c.Status(fiber.StatusConflict).JSON(models.APIResponse{
+				Status:  fiber.StatusConflict,
+				Message: "Failed to reorder menu",
+				Code:    errorCode(err),
+				Error:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
 			Status:  fiber.StatusInternalServerError,
 			Message: "Failed to reorder menu",
+			Code:    errorCode(err),
 			Error:   err.Error(),
 		})
 	}
@@ -366,3 +838,92 @@ func ReorderMenu(c *fiber.Ctx) error {
 		Data:    updated,
 	})
 }
+
+// AssignMenuIcons godoc
+// @Summary      Bulk-assign menu icons
+// @Description  Set the icon of many menu items at once from an ID-to-icon map. Unknown IDs are skipped and reported.
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AssignMenuIconsRequest  true  "Icon assignments"
+// @Success      200      {object}  models.APIResponse{data=dto.AssignMenuIconsResponse}
+// @Failure      400      {object}  models.APIResponse
+// @Failure      500      {object}  models.APIResponse
+// @Router       /api/menus/icons [patch]
+func AssignMenuIcons(c *fiber.Ctx) error {
+	req, err := utils.BindAndValidate[dto.AssignMenuIconsRequest](c)
+	if err != nil {
+		return err
+	}
+
+	menuService := services.NewMenuService(requestDB(c)).WithActor(currentUserID(c))
+	updated, unknownIDs, err := menuService.AssignIcons(req.Assignments)
+	if err != nil {
+		utils.Error(c.UserContext(), "request failed", "handler", "AssignMenuIcons", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to assign menu icons",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Menu icons assigned successfully",
+		Data: dto.AssignMenuIconsResponse{
+			Updated:    updated,
+			UnknownIDs: unknownIDs,
+		},
+	})
+}
+
+// ReorderSiblings godoc
+// @Summary      Reorder a whole sibling group
+// @Description  Assign order indexes to every child of a parent at once, by position in ordered_ids
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ReorderSiblingsRequest  true  "Batch reorder request"
+// @Success      200      {object}  models.APIResponse{data=[]models.Menu}
+// @Failure      400      {object}  models.APIResponse
+// @Failure      500      {object}  models.APIResponse
+// @Router       /api/menus/reorder-batch [patch]
+func ReorderSiblings(c *fiber.Ctx) error {
+	req, err := utils.BindAndValidate[dto.ReorderSiblingsRequest](c)
+	if err != nil {
+		return err
+	}
+
+	menuService := services.NewMenuService(requestDB(c)).WithActor(currentUserID(c))
+	if err := menuService.ReorderSiblings(req.ParentID, req.OrderedIDs); err != nil {
+		utils.Error(c.UserContext(), "request failed", "handler", "ReorderSiblings", "parent_id", req.ParentID, "error", err)
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrSiblingSetMismatch) {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(models.APIResponse{
+			Status:  status,
+			Message: "Failed to reorder menus",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	siblings, err := menuService.GetSiblings(req.ParentID)
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to reload siblings", "handler", "ReorderSiblings", "parent_id", req.ParentID, "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to fetch menus",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Menus reordered successfully",
+		Data:    siblings,
+	})
+}
@@ -0,0 +1,112 @@
+package handlers_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func bulkCreateMenus(t *testing.T, app *fiber.App, body string) (int, models.APIResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", "/api/menus/bulk", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	return resp.StatusCode, result
+}
+
+// groupTitles returns the titles of a sibling group in order_index order,
+// asserting the indexes run 0..n-1
+func groupTitles(t *testing.T, db *gorm.DB, query string, args ...interface{}) []string {
+	t.Helper()
+
+	var menus []models.Menu
+	db.Where(query, args...).Order("order_index").Find(&menus)
+
+	titles := make([]string, len(menus))
+	for i, menu := range menus {
+		testutil.AssertEqual(t, i, menu.OrderIndex, "order_index of "+menu.Title)
+		titles[i] = menu.Title
+	}
+	return titles
+}
+
+func TestBulkCreateMenus_RootsAndChildren(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	existing := testutil.CreateMenuFixture(db, "Existing", nil, 0)
+
+	status, result := bulkCreateMenus(t, app, `[
+		{"title": "Root A"},
+		{"title": "Root B"},
+		{"title": "A1", "parent_index": 0},
+		{"title": "A2", "parent_index": 0},
+		{"title": "E1", "parent_id": "`+existing.ID.String()+`"}
+	]`)
+
+	testutil.AssertEqual(t, fiber.StatusCreated, status, result.Error)
+	testutil.AssertLen(t, result.Data, 5)
+
+	testutil.AssertEqual(t, []string{"Existing", "Root A", "Root B"}, groupTitles(t, db, "parent_id IS NULL"))
+
+	var rootA models.Menu
+	db.Where("title = ?", "Root A").First(&rootA)
+	testutil.AssertEqual(t, []string{"A1", "A2"}, groupTitles(t, db, "parent_id = ?", rootA.ID))
+	testutil.AssertEqual(t, []string{"E1"}, groupTitles(t, db, "parent_id = ?", existing.ID))
+}
+
+func TestBulkCreateMenus_InvalidItem(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	status, result := bulkCreateMenus(t, app, `[
+		{"title": "Valid"},
+		{"title": "  "},
+		{"title": "Orphan", "parent_index": 5}
+	]`)
+
+	testutil.AssertEqual(t, fiber.StatusBadRequest, status)
+	testutil.AssertEqual(t, models.CodeValidationFailed, result.Code)
+	testutil.AssertEqual(t, "menu 1: title is required and cannot be empty", result.Errors["[1].title"])
+	testutil.AssertNotEmpty(t, result.Errors["[2].parent_index"])
+
+	var count int64
+	db.Model(&models.Menu{}).Count(&count)
+	testutil.AssertEqual(t, int64(0), count)
+}
+
+func TestBulkCreateMenus_RollsBackOnFailure(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+
+	status, result := bulkCreateMenus(t, app, `[
+		{"title": "First"},
+		{"title": "Second", "parent_index": 0},
+		{"title": "Duplicate", "path": "/dashboard"}
+	]`)
+
+	testutil.AssertEqual(t, fiber.StatusConflict, status)
+	testutil.AssertEqual(t, models.CodeMenuPathTaken, result.Code)
+	testutil.AssertEqual(t, "menu path already in use", result.Errors["[2]"])
+
+	var count int64
+	db.Model(&models.Menu{}).Count(&count)
+	testutil.AssertEqual(t, int64(1), count, "No menu of the batch should be created")
+}
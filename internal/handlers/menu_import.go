@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/andhikadk/stk-test-be/internal/dto"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/services"
+	"github.com/andhikadk/stk-test-be/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ExportMenus godoc
+// @Summary      Export menu tree
+// @Description  Download the whole live menu tree as a nested JSON document that can be imported again
+// @Tags         Menus
+// @Produce      json
+// @Success      200  {object}  models.MenuExport
+// @Failure      500  {object}  models.APIResponse
+// @Router       /api/menus/export [get]
+func ExportMenus(c *fiber.Ctx) error {
+	menuService := services.NewMenuService(requestDB(c))
+	export, err := menuService.ExportTree()
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to export menu tree", "handler", "ExportMenus", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to export menus",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	c.Attachment("menus.json")
+	return c.Status(fiber.StatusOK).JSON(export)
+}
+
+// ImportMenus godoc
+// @Summary      Import menu tree
+// @Description  Import a document produced by the export endpoint in a single transaction. replace wipes all menus first and needs the admin token; merge updates the menu with the same path, or else the same id, and creates the rest, placing them after the existing menus of each sibling group. Menus without an id get a new one.
+// @Tags         Menus
+// @Accept       json
+// @Produce      json
+// @Param        mode           query     string                  false  "Import mode"  Enums(replace, merge)  default(merge)
+// @Param        X-Admin-Token  header    string                  false  "Admin token, required by replace"
+// @Param        request        body      dto.ImportMenusRequest  true   "Menu tree document"
+// @Success      200            {object}  models.APIResponse{data=dto.ImportMenusResponse}
+// @Failure      400            {object}  models.APIResponse
+// @Failure      401            {object}  models.APIResponse
+// @Failure      403            {object}  models.APIResponse
+// @Failure      409            {object}  models.APIResponse
+// @Failure      500            {object}  models.APIResponse
+// @Router       /api/menus/import [post]
+func ImportMenus(c *fiber.Ctx) error {
+	req, err := utils.BindAndValidate[dto.ImportMenusRequest](c)
+	if err != nil {
+		return err
+	}
+
+	mode := services.ImportMode(c.Query("mode", string(services.ImportModeMerge)))
+
+	menuService := services.NewMenuService(requestDB(c))
+	created, updated, err := menuService.ImportTree(req.Menus, mode)
+	if err != nil {
+		utils.Error(c.UserContext(), "request failed", "handler", "ImportMenus", "mode", mode, "error", err)
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidImportMode) || errors.Is(err, services.ErrMenuDepthExceeded) {
+			status = fiber.StatusBadRequest
+		} else if errors.Is(err, services.ErrMenuPathTaken) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(models.APIResponse{
+			Status:  status,
+			Message: "Failed to import menus",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Menus imported successfully",
+		Data: dto.ImportMenusResponse{
+			Created: created,
+			Updated: updated,
+		},
+	})
+}
@@ -0,0 +1,287 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+	"github.com/google/uuid"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func exportMenus(t *testing.T, app *fiber.App) models.MenuExport {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/menus/export", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var export models.MenuExport
+	testutil.ParseJSONResponse(t, resp.Body, &export)
+	return export
+}
+
+func importMenus(t *testing.T, app *fiber.App, mode string, doc interface{}) (int, models.APIResponse) {
+	t.Helper()
+
+	body, _ := json.Marshal(doc)
+	req := adminRequest("POST", "/api/menus/import?mode="+mode, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	return resp.StatusCode, result
+}
+
+func TestExportMenus_NestedDocument(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	hierarchy := testutil.CreateMultiLevelHierarchy(db)
+
+	export := exportMenus(t, app)
+
+	testutil.AssertLen(t, export.Menus, 2)
+	root := export.Menus[0]
+	testutil.AssertEqual(t, hierarchy["root1"].ID, *root.ID)
+	testutil.AssertLen(t, root.Children, 2)
+	testutil.AssertEqual(t, "Grandchild 1.1.1", root.Children[0].Children[0].Title)
+}
+
+func TestImportMenus_ReplaceRoundTrip(t *testing.T) {
+	app, db, cleanup := setupAdminTest(t)
+	defer cleanup()
+
+	hierarchy := testutil.CreateMultiLevelHierarchy(db)
+	export := exportMenus(t, app)
+
+	testutil.CreateMenuFixture(db, "Added Later", nil, 2)
+
+	status, result := importMenus(t, app, "replace", export)
+
+	testutil.AssertEqual(t, fiber.StatusOK, status)
+	data := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, float64(5), data["created"])
+	testutil.AssertEqual(t, float64(0), data["updated"])
+
+	var count int64
+	db.Model(&models.Menu{}).Count(&count)
+	testutil.AssertEqual(t, int64(5), count)
+
+	var grandchild models.Menu
+	db.Where("id = ?", hierarchy["grandchild1_1_1"].ID).First(&grandchild)
+	testutil.AssertEqual(t, hierarchy["child1_1"].ID, *grandchild.ParentID)
+}
+
+func TestImportMenus_Merge(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	existing := testutil.CreateMenuFixture(db, "Existing", nil, 0)
+	untouched := testutil.CreateMenuFixture(db, "Untouched", nil, 1)
+
+	doc := models.MenuExport{
+		Menus: []models.MenuTreeNode{
+			{
+				ID:    &existing.ID,
+				Title: "Renamed",
+				Children: []models.MenuTreeNode{
+					{Title: "New Child"},
+				},
+			},
+		},
+	}
+
+	status, result := importMenus(t, app, "merge", doc)
+
+	testutil.AssertEqual(t, fiber.StatusOK, status)
+	data := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, float64(1), data["created"])
+	testutil.AssertEqual(t, float64(1), data["updated"])
+
+	var renamed models.Menu
+	db.Where("id = ?", existing.ID).First(&renamed)
+	testutil.AssertEqual(t, "Renamed", renamed.Title)
+
+	var child models.Menu
+	db.Where("parent_id = ?", existing.ID).First(&child)
+	testutil.AssertEqual(t, "New Child", child.Title)
+	testutil.AssertNotEqual(t, uuid.Nil, child.ID)
+
+	var kept int64
+	db.Model(&models.Menu{}).Where("id = ?", untouched.ID).Count(&kept)
+	testutil.AssertEqual(t, int64(1), kept)
+}
+
+func TestImportMenus_MergeAppendsAfterExistingSiblings(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	first := testutil.CreateMenuFixture(db, "First", nil, 0)
+	second := testutil.CreateMenuFixture(db, "Second", nil, 1)
+	testutil.CreateMenuFixture(db, "Child", &first.ID, 0)
+
+	doc := models.MenuExport{
+		Menus: []models.MenuTreeNode{
+			{Title: "Imported A"},
+			{Title: "Imported B", Children: []models.MenuTreeNode{
+				{ID: &second.ID, Title: "Second"},
+			}},
+		},
+	}
+
+	status, result := importMenus(t, app, "merge", doc)
+
+	testutil.AssertEqual(t, fiber.StatusOK, status, result.Error)
+	testutil.AssertEqual(t, []string{"First", "Imported A", "Imported B"}, groupTitles(t, db, "parent_id IS NULL"))
+	testutil.AssertEqual(t, []string{"Child"}, groupTitles(t, db, "parent_id = ?", first.ID))
+}
+
+func TestImportMenus_DuplicatePath(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+
+	path := "/dashboard"
+	doc := models.MenuExport{
+		Menus: []models.MenuTreeNode{
+			{Title: "Valid"},
+			{Title: "First", Path: &path},
+			{Title: "Duplicate", Path: &path},
+		},
+	}
+
+	status, result := importMenus(t, app, "merge", doc)
+
+	testutil.AssertEqual(t, fiber.StatusConflict, status)
+	testutil.AssertEqual(t, models.CodeMenuPathTaken, result.Code)
+
+	var menus []models.Menu
+	db.Find(&menus)
+	testutil.AssertLen(t, menus, 1, "The import should be rolled back")
+	testutil.AssertEqual(t, "Dashboard", menus[0].Title)
+}
+
+func TestImportMenus_MergeMatchesPathBeforeID(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	byPath := testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+	byID := testutil.CreateMenuFixture(db, "Settings", nil, 1)
+
+	// Exported from another environment, so the IDs differ
+	path := "/dashboard"
+	otherID := uuid.New()
+	doc := models.MenuExport{
+		Menus: []models.MenuTreeNode{
+			{ID: &otherID, Title: "Home", Path: &path},
+			{ID: &byID.ID, Title: "Preferences"},
+		},
+	}
+
+	status, result := importMenus(t, app, "merge", doc)
+
+	testutil.AssertEqual(t, fiber.StatusOK, status, result.Error)
+	data := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, float64(0), data["created"])
+	testutil.AssertEqual(t, float64(2), data["updated"])
+	testutil.AssertEqual(t, []string{"Home", "Preferences"}, groupTitles(t, db, "parent_id IS NULL"))
+
+	var home models.Menu
+	db.Where("id = ?", byPath.ID).First(&home)
+	testutil.AssertEqual(t, "Home", home.Title)
+}
+
+func TestImportMenus_MergeChecksDepthOfExistingChildren(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	withMaxDepth(t, 3)
+
+	top := testutil.CreateMenuFixture(db, "Top", nil, 0)
+	middle := testutil.CreateMenuFixture(db, "Middle", &top.ID, 0)
+	testutil.CreateMenuFixture(db, "Bottom", &middle.ID, 0)
+
+	// The document is two levels deep, but Top brings Middle and Bottom along
+	doc := models.MenuExport{
+		Menus: []models.MenuTreeNode{
+			{Title: "Wrapper", Children: []models.MenuTreeNode{
+				{ID: &top.ID, Title: "Top"},
+			}},
+		},
+	}
+
+	status, result := importMenus(t, app, "merge", doc)
+
+	testutil.AssertEqual(t, fiber.StatusBadRequest, status)
+	testutil.AssertEqual(t, models.CodeMenuDepthExceeded, result.Code)
+
+	var stored models.Menu
+	db.Where("id = ?", top.ID).First(&stored)
+	testutil.AssertNil(t, stored.ParentID, "The import should be rolled back")
+}
+
+func TestImportMenus_InvalidTitleLeavesMenusUntouched(t *testing.T) {
+	app, db, cleanup := setupAdminTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuFixture(db, "Existing", nil, 0)
+
+	doc := models.MenuExport{
+		Menus: []models.MenuTreeNode{
+			{Title: "Valid", Children: []models.MenuTreeNode{{Title: "  "}}},
+		},
+	}
+
+	status, _ := importMenus(t, app, "replace", doc)
+
+	testutil.AssertEqual(t, fiber.StatusBadRequest, status)
+
+	var count int64
+	db.Model(&models.Menu{}).Count(&count)
+	testutil.AssertEqual(t, int64(1), count, "Existing menus should be untouched")
+}
+
+func TestImportMenus_InvalidMode(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	status, _ := importMenus(t, app, "append", models.MenuExport{})
+
+	testutil.AssertEqual(t, fiber.StatusBadRequest, status)
+}
+
+func TestImportMenus_ReplaceDisabledWithoutAdminToken(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuFixture(db, "Existing", nil, 0)
+
+	doc := models.MenuExport{Menus: []models.MenuTreeNode{{Title: "Imported"}}}
+
+	status, result := importMenus(t, app, "replace", doc)
+
+	testutil.AssertEqual(t, fiber.StatusForbidden, status)
+	testutil.AssertEqual(t, models.CodeForbidden, result.Code)
+
+	// Merge imports delete nothing, so they stay open
+	status, _ = importMenus(t, app, "merge", doc)
+	testutil.AssertEqual(t, fiber.StatusOK, status)
+
+	testutil.AssertEqual(t, []string{"Existing", "Imported"}, groupTitles(t, db, "parent_id IS NULL"))
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/andhikadk/stk-test-be/internal/dto"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/services"
+	"github.com/andhikadk/stk-test-be/internal/utils"
+	"github.com/google/uuid"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMenuPresets godoc
+// @Summary      List menu presets
+// @Description  Get all saved menu tree presets
+// @Tags         Menu Presets
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.APIResponse{data=[]models.MenuPreset}
+// @Failure      500  {object}  models.APIResponse
+// @Router       /api/menus/presets [get]
+func GetMenuPresets(c *fiber.Ctx) error {
+	presetService := services.NewMenuPresetService(requestDB(c))
+	presets, err := presetService.GetAllPresets()
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to fetch presets", "handler", "GetMenuPresets", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to fetch menu presets",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Menu presets retrieved successfully",
+		Data:    presets,
+	})
+}
+
+// CreateMenuPreset godoc
+// @Summary      Save menu preset
+// @Description  Save a snapshot of the current menu tree under a name
+// @Tags         Menu Presets
+// @Accept       json
+// @Produce      json
+// @Param        preset  body      dto.CreateMenuPresetRequest  true  "Preset data"
+// @Success      201     {object}  models.APIResponse{data=models.MenuPreset}
+// @Failure      400     {object}  models.APIResponse
+// @Failure      409     {object}  models.APIResponse
+// @Failure      500     {object}  models.APIResponse
+// @Router       /api/menus/presets [post]
+func CreateMenuPreset(c *fiber.Ctx) error {
+	req, err := utils.BindAndValidate[dto.CreateMenuPresetRequest](c)
+	if err != nil {
+		return err
+	}
+
+	presetService := services.NewMenuPresetService(requestDB(c))
+	preset, err := presetService.SavePreset(strings.TrimSpace(req.Name))
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to save preset", "handler", "CreateMenuPreset", "name", req.Name, "error", err)
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrPresetNameTaken) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(models.APIResponse{
+			Status:  status,
+			Message: "Failed to save menu preset",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.APIResponse{
+		Status:  fiber.StatusCreated,
+		Message: "Menu preset saved successfully",
+		Data:    preset,
+	})
+}
+
+// ApplyMenuPreset godoc
+// @Summary      Apply menu preset
+// @Description  Replace the live menu tree with a saved preset, generating new IDs
+// @Tags         Menu Presets
+// @Accept       json
+// @Produce      json
+// @Param        id             path      string  true  "Preset ID (UUID format)"  Format(uuid)
+// @Param        X-Admin-Token  header    string  true  "Admin token"
+// @Success      200            {object}  models.APIResponse{data=[]models.Menu}
+// @Failure      400            {object}  models.APIResponse
+// @Failure      401            {object}  models.APIResponse
+// @Failure      403            {object}  models.APIResponse
+// @Failure      404            {object}  models.APIResponse
+// @Failure      409            {object}  models.APIResponse
+// @Failure      500            {object}  models.APIResponse
+// @Router       /api/menus/presets/{id}/apply [post]
+func ApplyMenuPreset(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.APIResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid preset ID",
+			Code:    models.CodeInvalidID,
+			Error:   err.Error(),
+		})
+	}
+
+	presetService := services.NewMenuPresetService(requestDB(c))
+	if err := presetService.ApplyPreset(id); err != nil {
+		utils.Error(c.UserContext(), "request failed", "handler", "ApplyMenuPreset", "preset_id", id, "error", err)
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrPresetNotFound) {
+			status = fiber.StatusNotFound
+		} else if errors.Is(err, services.ErrMenuDepthExceeded) {
+			status = fiber.StatusBadRequest
+		} else if errors.Is(err, services.ErrMenuPathTaken) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(models.APIResponse{
+			Status:  status,
+			Message: "Failed to apply menu preset",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	menus, err := services.NewMenuService(requestDB(c)).GetMenuTree(services.TreeOptions{})
+	if err != nil {
+		utils.Error(c.UserContext(), "failed to reload tree", "handler", "ApplyMenuPreset", "preset_id", id, "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.APIResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to fetch menus",
+			Code:    errorCode(err),
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(models.APIResponse{
+		Status:  fiber.StatusOK,
+		Message: "Menu preset applied successfully",
+		Data:    menus,
+	})
+}
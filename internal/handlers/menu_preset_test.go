@@ -0,0 +1,173 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/dto"
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func savePreset(t *testing.T, app *fiber.App, name string) map[string]interface{} {
+	t.Helper()
+
+	body, _ := json.Marshal(dto.CreateMenuPresetRequest{Name: name})
+	req := httptest.NewRequest("POST", "/api/menus/presets", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	return result.Data.(map[string]interface{})
+}
+
+func TestCreateMenuPreset_Success(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMultiLevelHierarchy(db)
+
+	preset := savePreset(t, app, "Tenant A")
+
+	testutil.AssertEqual(t, "Tenant A", preset["name"])
+	testutil.AssertNotNil(t, preset["id"])
+}
+
+func TestCreateMenuPreset_DuplicateName(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	savePreset(t, app, "Tenant A")
+
+	body, _ := json.Marshal(dto.CreateMenuPresetRequest{Name: "Tenant A"})
+	req := httptest.NewRequest("POST", "/api/menus/presets", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusConflict, resp)
+}
+
+func TestGetMenuPresets_List(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	savePreset(t, app, "Tenant A")
+	savePreset(t, app, "Tenant B")
+
+	req := httptest.NewRequest("GET", "/api/menus/presets", nil)
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	presets := result.Data.([]interface{})
+	testutil.AssertLen(t, presets, 2)
+	testutil.AssertEqual(t, "Tenant A", presets[0].(map[string]interface{})["name"])
+	testutil.AssertEqual(t, "Tenant B", presets[1].(map[string]interface{})["name"])
+}
+
+func TestApplyMenuPreset_RestoresTree(t *testing.T) {
+	app, db, cleanup := setupAdminTest(t)
+	defer cleanup()
+
+	hierarchy := testutil.CreateMultiLevelHierarchy(db)
+	preset := savePreset(t, app, "Original")
+
+	db.Where("1 = 1").Delete(&models.Menu{})
+	testutil.CreateMenuFixture(db, "Replacement", nil, 0)
+
+	url := fmt.Sprintf("/api/menus/presets/%s/apply", preset["id"])
+	req := adminRequest("POST", url, nil)
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, "Menu preset applied successfully", result.Message)
+
+	menus := result.Data.([]interface{})
+	testutil.AssertLen(t, menus, 2, "Should have 2 root menus")
+
+	root1 := menus[0].(map[string]interface{})
+	testutil.AssertEqual(t, "Root 1", root1["title"])
+	testutil.AssertNotEqual(t, hierarchy["root1"].ID.String(), root1["id"], "Applied menus should get fresh IDs")
+
+	root1Children := root1["children"].([]interface{})
+	testutil.AssertLen(t, root1Children, 2)
+
+	child1_1 := root1Children[0].(map[string]interface{})
+	testutil.AssertEqual(t, "Child 1.1", child1_1["title"])
+	testutil.AssertLen(t, child1_1["children"].([]interface{}), 1)
+
+	var total int64
+	db.Model(&models.Menu{}).Count(&total)
+	testutil.AssertEqual(t, int64(5), total, "Replacement menu should be gone")
+
+	var stored int64
+	db.Unscoped().Model(&models.Menu{}).Count(&stored)
+	testutil.AssertEqual(t, int64(5), stored, "The old tree should be hard-deleted, as on import replace")
+}
+
+func TestApplyMenuPreset_NotFound(t *testing.T) {
+	app, _, cleanup := setupAdminTest(t)
+	defer cleanup()
+
+	req := adminRequest("POST", "/api/menus/presets/123e4567-e89b-12d3-a456-426614174000/apply", nil)
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusNotFound, resp)
+}
+
+func TestApplyMenuPreset_RequiresAdminToken(t *testing.T) {
+	app, _, cleanup := setupAdminTest(t)
+	defer cleanup()
+
+	preset := savePreset(t, app, "Original")
+
+	url := fmt.Sprintf("/api/menus/presets/%s/apply", preset["id"])
+	req := httptest.NewRequest("POST", url, nil)
+	req.Header.Set(middleware.HeaderAdminToken, "wrong-token")
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusUnauthorized, resp)
+}
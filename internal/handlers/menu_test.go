@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/andhikadk/stk-test-be/config"
 	"github.com/andhikadk/stk-test-be/internal/database"
 	"github.com/andhikadk/stk-test-be/internal/dto"
+	"github.com/andhikadk/stk-test-be/internal/middleware"
 	"github.com/andhikadk/stk-test-be/internal/models"
 	"github.com/andhikadk/stk-test-be/internal/routes"
 	"github.com/andhikadk/stk-test-be/internal/testutil"
@@ -27,6 +33,7 @@ func setupTest(t *testing.T) (*fiber.App, *gorm.DB, func()) {
 	testutil.InitTestLogger()
 
 	app := fiber.New()
+	app.Use(middleware.ErrorHandlingMiddleware())
 	routes.SetupRoutes(app)
 
 	cleanup := func() {
@@ -37,6 +44,28 @@ func setupTest(t *testing.T) (*fiber.App, *gorm.DB, func()) {
 	return app, db, cleanup
 }
 
+// testAdminToken is the admin token configured by setupAdminTest
+const testAdminToken = "test-admin-token"
+
+// setupAdminTest is setupTest with an admin token configured, for the
+// endpoints behind the admin guard
+func setupAdminTest(t *testing.T) (*fiber.App, *gorm.DB, func()) {
+	t.Helper()
+	originalConfig := config.AppConfig
+	config.AppConfig = &config.Config{AdminToken: testAdminToken}
+	t.Cleanup(func() {
+		config.AppConfig = originalConfig
+	})
+	return setupTest(t)
+}
+
+// adminRequest builds a request carrying the test admin token
+func adminRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set(middleware.HeaderAdminToken, testAdminToken)
+	return req
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -49,6 +78,10 @@ func uuidPtr(u uuid.UUID) *uuid.UUID {
 	return &u
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func TestGetMenus_EmptyDatabase(t *testing.T) {
 	app, _, cleanup := setupTest(t)
 	defer cleanup()
@@ -211,6 +244,7 @@ func TestGetMenu_NotFound(t *testing.T) {
 	testutil.ParseJSONResponse(t, resp.Body, &result)
 
 	testutil.AssertEqual(t, "Menu not found", result.Message)
+	testutil.AssertEqual(t, models.CodeMenuNotFound, result.Code)
 	testutil.AssertNotEmpty(t, result.Error)
 }
 
@@ -231,6 +265,7 @@ func TestGetMenu_InvalidID(t *testing.T) {
 	testutil.ParseJSONResponse(t, resp.Body, &result)
 
 	testutil.AssertEqual(t, "Invalid menu ID", result.Message)
+	testutil.AssertEqual(t, models.CodeInvalidID, result.Code)
 }
 
 func TestGetMenu_WithChildren(t *testing.T) {
@@ -257,6 +292,68 @@ func TestGetMenu_WithChildren(t *testing.T) {
 	testutil.AssertLen(t, children, 3, "Parent should have 3 children")
 }
 
+func TestGetMenu_WithGrandchildren(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	menus := testutil.CreateMultiLevelHierarchy(db)
+	url := fmt.Sprintf("/api/menus/%s", menus["root1"].ID)
+
+	tests := []struct {
+		name              string
+		query             string
+		wantGrandchildren int
+	}{
+		{name: "whole subtree", query: "", wantGrandchildren: 1},
+		{name: "depth 2", query: "?depth=2", wantGrandchildren: 1},
+		{name: "depth 1", query: "?depth=1", wantGrandchildren: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", url+tt.query, nil))
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+			var result struct {
+				Data dto.MenuDetailResponse `json:"data"`
+			}
+			testutil.ParseJSONResponse(t, resp.Body, &result)
+
+			children := result.Data.Children
+			testutil.AssertLen(t, children, 2, "Root should have 2 children")
+			testutil.AssertEqual(t, menus["child1_1"].ID, children[0].ID)
+			testutil.AssertLen(t, children[0].Children, tt.wantGrandchildren)
+			if tt.wantGrandchildren > 0 {
+				testutil.AssertEqual(t, menus["grandchild1_1_1"].ID, children[0].Children[0].ID)
+			}
+			testutil.AssertEqual(t, int64(3), result.Data.DescendantCount)
+		})
+	}
+}
+
+func TestGetMenu_NegativeDepth(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	menu := testutil.CreateMenuFixture(db, "Menu", nil, 0)
+
+	resp, err := app.Test(httptest.NewRequest("GET", fmt.Sprintf("/api/menus/%s?depth=-1", menu.ID), nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, models.CodeValidationFailed, result.Code)
+}
+
 func TestCreateMenu_Success(t *testing.T) {
 	app, _, cleanup := setupTest(t)
 	defer cleanup()
@@ -295,6 +392,7 @@ func TestCreateMenu_Success(t *testing.T) {
 	testutil.AssertEqual(t, *reqBody.Path, menuData["path"])
 	testutil.AssertEqual(t, *reqBody.Icon, menuData["icon"])
 	testutil.AssertNotNil(t, menuData["id"])
+	testutil.AssertEqual(t, fmt.Sprintf("/api/menus/%s", menuData["id"]), resp.Header.Get(fiber.HeaderLocation))
 }
 
 func TestCreateMenu_WithParent(t *testing.T) {
@@ -407,6 +505,36 @@ func TestCreateMenu_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestCreateMenu_ValidationErrorsByField(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"title":       "",
+		"order_index": -1,
+	})
+	req := httptest.NewRequest("POST", "/api/menus", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, "Validation failed", result.Message)
+	testutil.AssertEqual(t, models.CodeValidationFailed, result.Code)
+	testutil.AssertEqual(t, 2, len(result.Errors))
+	testutil.AssertEqual(t, "title is required and cannot be empty", result.Errors["title"])
+	testutil.AssertEqual(t, "order_index must be a non-negative integer", result.Errors["order_index"])
+	testutil.AssertContains(t, result.Error, "title is required")
+	testutil.AssertContains(t, result.Error, "order_index must be a non-negative integer")
+}
+
 func TestCreateMenu_InvalidJSON(t *testing.T) {
 	app, _, cleanup := setupTest(t)
 	defer cleanup()
@@ -428,6 +556,131 @@ func TestCreateMenu_InvalidJSON(t *testing.T) {
 	testutil.AssertEqual(t, "Invalid request body", result.Message)
 }
 
+func TestCreateMenu_IdempotencyKey(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	create := func() (*http.Response, []byte) {
+		req := httptest.NewRequest("POST", "/api/menus", strings.NewReader(`{"title": "Reports", "path": "/reports"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.HeaderIdempotencyKey, "create-reports")
+
+		resp, err := app.Test(req)
+
+		if err != nil {
+			t.Fatalf("Failed to perform request: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return resp, body
+	}
+
+	first, firstBody := create()
+	second, secondBody := create()
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, first)
+	testutil.AssertStatusCode(t, fiber.StatusCreated, second)
+	testutil.AssertEqual(t, string(firstBody), string(secondBody))
+	testutil.AssertEqual(t, first.Header.Get(fiber.HeaderLocation), second.Header.Get(fiber.HeaderLocation))
+
+	var count int64
+	db.Model(&models.Menu{}).Count(&count)
+	testutil.AssertEqual(t, int64(1), count, "The retry should not create a second menu")
+}
+
+func TestCreateMenu_BodyTooLarge(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		BodyLimit:             1024,
+		ErrorHandler:          middleware.ErrorHandler,
+		DisableStartupMessage: true,
+	})
+	routes.SetupRoutes(app)
+
+	// app.Test returns the server's body limit error instead of the
+	// response, so serve over a real listener
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go app.Listener(ln)
+	defer app.Shutdown()
+
+	body := fmt.Sprintf(`{"title": %q}`, strings.Repeat("a", 2048))
+	resp, err := http.Post("http://"+ln.Addr().String()+"/api/menus", "application/json", strings.NewReader(body))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	testutil.AssertStatusCode(t, fiber.StatusRequestEntityTooLarge, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, fiber.StatusRequestEntityTooLarge, result.Status)
+	testutil.AssertEqual(t, models.CodePayloadTooLarge, result.Code)
+}
+
+func upsertMenu(t *testing.T, app *fiber.App, body string) (*http.Response, models.APIResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", "/api/menus?upsert=true", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	return resp, result
+}
+
+func TestCreateMenu_UpsertCreates(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+
+	resp, result := upsertMenu(t, app, `{"title": "Reports", "path": "/reports"}`)
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+	testutil.AssertEqual(t, "Menu created successfully", result.Message)
+
+	menuData := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, "Reports", menuData["title"])
+	testutil.AssertEqual(t, fmt.Sprintf("/api/menus/%s", menuData["id"]), resp.Header.Get(fiber.HeaderLocation))
+
+	var count int64
+	db.Model(&models.Menu{}).Count(&count)
+	testutil.AssertEqual(t, int64(2), count)
+}
+
+func TestCreateMenu_UpsertUpdatesExistingPath(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	existing := testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+
+	resp, result := upsertMenu(t, app, `{"title": "Home", "path": "/dashboard", "is_active": false}`)
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+	testutil.AssertEqual(t, "Menu updated successfully", result.Message)
+	testutil.AssertEqual(t, "", resp.Header.Get(fiber.HeaderLocation))
+
+	menuData := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, existing.ID.String(), menuData["id"])
+	testutil.AssertEqual(t, "Home", menuData["title"])
+	testutil.AssertEqual(t, false, menuData["is_active"])
+	testutil.AssertEqual(t, "icon-dashboard", menuData["icon"], "Fields not sent should keep their value")
+
+	var count int64
+	db.Model(&models.Menu{}).Count(&count)
+	testutil.AssertEqual(t, int64(1), count, "The upsert should not create a duplicate")
+}
+
 func TestUpdateMenu_Success(t *testing.T) {
 	app, db, cleanup := setupTest(t)
 	defer cleanup()
@@ -496,6 +749,48 @@ func TestUpdateMenu_ChangeParent(t *testing.T) {
 	testutil.AssertEqual(t, parent2.ID.String(), menuData["parent_id"])
 }
 
+func TestUpdateMenu_ChangeParentKeepsGroupsContiguous(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		wantB []string
+	}{
+		{name: "appended", body: `{"parent_id": "%s"}`, wantB: []string{"B1", "B2", "A2"}},
+		{name: "at index", body: `{"parent_id": "%s", "order_index": 1}`, wantB: []string{"B1", "A2", "B2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, db, cleanup := setupTest(t)
+			defer cleanup()
+
+			parentA := testutil.CreateMenuFixture(db, "Parent A", nil, 0)
+			parentB := testutil.CreateMenuFixture(db, "Parent B", nil, 1)
+			testutil.CreateMenuFixture(db, "A1", &parentA.ID, 0)
+			moved := testutil.CreateMenuFixture(db, "A2", &parentA.ID, 1)
+			testutil.CreateMenuFixture(db, "A3", &parentA.ID, 2)
+			testutil.CreateMenuFixture(db, "B1", &parentB.ID, 0)
+			testutil.CreateMenuFixture(db, "B2", &parentB.ID, 1)
+
+			body := fmt.Sprintf(tt.body, parentB.ID)
+			url := fmt.Sprintf("/api/menus/%s", moved.ID)
+			req := httptest.NewRequest("PUT", url, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+			testutil.AssertEqual(t, []string{"A1", "A3"}, groupTitles(t, db, "parent_id = ?", parentA.ID))
+			testutil.AssertEqual(t, tt.wantB, groupTitles(t, db, "parent_id = ?", parentB.ID))
+		})
+	}
+}
+
 func TestUpdateMenu_MoveToRoot(t *testing.T) {
 	app, db, cleanup := setupTest(t)
 	defer cleanup()
@@ -503,11 +798,7 @@ func TestUpdateMenu_MoveToRoot(t *testing.T) {
 	parent := testutil.CreateMenuFixture(db, "Parent", nil, 0)
 	child := testutil.CreateMenuFixture(db, "Child", &parent.ID, 0)
 
-	reqBody := dto.UpdateMenuRequest{
-		ParentID: nil,
-	}
-
-	body, _ := json.Marshal(reqBody)
+	body := []byte(`{"parent_id": null}`)
 	url := fmt.Sprintf("/api/menus/%s", child.ID)
 	req := httptest.NewRequest("PUT", url, bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -547,12 +838,13 @@ func TestUpdateMenu_NotFound(t *testing.T) {
 		t.Fatalf("Failed to perform request: %v", err)
 	}
 
-	testutil.AssertStatusCode(t, fiber.StatusInternalServerError, resp)
+	testutil.AssertStatusCode(t, fiber.StatusNotFound, resp)
 
 	var result models.APIResponse
 	testutil.ParseJSONResponse(t, resp.Body, &result)
 
 	testutil.AssertEqual(t, "Failed to update menu", result.Message)
+	testutil.AssertEqual(t, models.CodeMenuNotFound, result.Code)
 }
 
 func TestUpdateMenu_ValidationErrors(t *testing.T) {
@@ -964,3 +1256,1430 @@ func TestReorderMenu_WithinSiblings(t *testing.T) {
 	testutil.AssertEqual(t, float64(2), menuData["order_index"])
 	testutil.AssertEqual(t, parent.ID.String(), menuData["parent_id"])
 }
+
+func withMaxDepth(t *testing.T, depth int) {
+	t.Helper()
+	originalConfig := config.AppConfig
+	config.AppConfig = &config.Config{MenuMaxDepth: depth}
+	t.Cleanup(func() {
+		config.AppConfig = originalConfig
+	})
+}
+
+func TestCreateMenu_AtMaxDepth(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	withMaxDepth(t, 3)
+
+	root := testutil.CreateMenuFixture(db, "Root", nil, 0)
+	child := testutil.CreateMenuFixture(db, "Child", &root.ID, 0)
+
+	reqBody := dto.CreateMenuRequest{
+		Title:    "Grandchild",
+		ParentID: &child.ID,
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/api/menus", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+}
+
+func TestCreateMenu_ExceedsMaxDepth(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	withMaxDepth(t, 3)
+
+	root := testutil.CreateMenuFixture(db, "Root", nil, 0)
+	child := testutil.CreateMenuFixture(db, "Child", &root.ID, 0)
+	grandchild := testutil.CreateMenuFixture(db, "Grandchild", &child.ID, 0)
+
+	reqBody := dto.CreateMenuRequest{
+		Title:    "Too Deep",
+		ParentID: &grandchild.ID,
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/api/menus", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertContains(t, result.Error, "menu depth limit exceeded")
+}
+
+func TestMoveMenu_ExceedsMaxDepth(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	withMaxDepth(t, 3)
+
+	root := testutil.CreateMenuFixture(db, "Root", nil, 0)
+	child := testutil.CreateMenuFixture(db, "Child", &root.ID, 0)
+	other := testutil.CreateMenuFixture(db, "Other", nil, 1)
+	testutil.CreateMenuFixture(db, "Other Child", &other.ID, 0)
+
+	reqBody := dto.MoveMenuRequest{
+		ParentID: &child.ID,
+	}
+
+	body, _ := json.Marshal(reqBody)
+	url := fmt.Sprintf("/api/menus/%s/move", other.ID)
+	req := httptest.NewRequest("PATCH", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertContains(t, result.Error, "menu depth limit exceeded")
+}
+
+func TestMoveMenu_IntoOwnDescendant(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	root := testutil.CreateMenuFixture(db, "Root", nil, 0)
+	child := testutil.CreateMenuFixture(db, "Child", &root.ID, 0)
+
+	body, _ := json.Marshal(dto.MoveMenuRequest{ParentID: &child.ID})
+	url := fmt.Sprintf("/api/menus/%s/move", root.ID)
+	req := httptest.NewRequest("PATCH", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, models.CodeMenuCycle, result.Code)
+
+	var stored models.Menu
+	db.Where("id = ?", root.ID).First(&stored)
+	testutil.AssertNil(t, stored.ParentID)
+}
+
+func TestUpdateMenu_ParentValidation(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	withMaxDepth(t, 3)
+
+	root := testutil.CreateMenuFixture(db, "Root", nil, 0)
+	child := testutil.CreateMenuFixture(db, "Child", &root.ID, 0)
+	other := testutil.CreateMenuFixture(db, "Other", nil, 1)
+	testutil.CreateMenuFixture(db, "Other Child", &other.ID, 0)
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		parentID uuid.UUID
+		code     string
+	}{
+		{"into own descendant", root.ID, child.ID, models.CodeMenuCycle},
+		{"under itself", root.ID, root.ID, models.CodeMenuCycle},
+		{"beyond max depth", other.ID, child.ID, models.CodeMenuDepthExceeded},
+		{"missing parent", other.ID, uuid.New(), models.CodeParentMenuNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := fmt.Sprintf(`{"parent_id": %q}`, tt.parentID)
+			url := fmt.Sprintf("/api/menus/%s", tt.id)
+			req := httptest.NewRequest("PUT", url, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+
+			var result models.APIResponse
+			testutil.ParseJSONResponse(t, resp.Body, &result)
+
+			testutil.AssertEqual(t, tt.code, result.Code)
+		})
+	}
+
+	testutil.AssertEqual(t, []string{"Root", "Other"}, groupTitles(t, db, "parent_id IS NULL"))
+}
+
+func TestGetMenu_ChildAndDescendantCounts(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	hierarchy := testutil.CreateMultiLevelHierarchy(db)
+
+	url := fmt.Sprintf("/api/menus/%s", hierarchy["root1"].ID)
+	req := httptest.NewRequest("GET", url, nil)
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	menuData := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, hierarchy["root1"].Title, menuData["title"])
+	testutil.AssertEqual(t, float64(2), menuData["child_count"])
+	testutil.AssertEqual(t, float64(3), menuData["descendant_count"])
+}
+
+func TestGetMenu_LeafCounts(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	hierarchy := testutil.CreateMultiLevelHierarchy(db)
+
+	url := fmt.Sprintf("/api/menus/%s", hierarchy["grandchild1_1_1"].ID)
+	req := httptest.NewRequest("GET", url, nil)
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	menuData := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, float64(0), menuData["child_count"])
+	testutil.AssertEqual(t, float64(0), menuData["descendant_count"])
+}
+
+func TestUpdateMenu_ClearIcon(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	menu := testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+
+	body := []byte(`{"icon": null}`)
+	url := fmt.Sprintf("/api/menus/%s", menu.ID)
+	req := httptest.NewRequest("PUT", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	menuData := result.Data.(map[string]interface{})
+	testutil.AssertNil(t, menuData["icon"], "Icon should be cleared")
+	testutil.AssertEqual(t, "/dashboard", menuData["path"], "Path should be untouched")
+	testutil.AssertEqual(t, "Dashboard", menuData["title"], "Title should be untouched")
+}
+
+func TestUpdateMenu_OrderIndexZero(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent := testutil.CreateMenuFixture(db, "Parent", nil, 0)
+	first := testutil.CreateMenuFixture(db, "First", &parent.ID, 0)
+	middle := testutil.CreateMenuFixture(db, "Middle", &parent.ID, 1)
+	last := testutil.CreateMenuFixture(db, "Last", &parent.ID, 2)
+
+	body := []byte(`{"order_index": 0}`)
+	url := fmt.Sprintf("/api/menus/%s", middle.ID)
+	req := httptest.NewRequest("PUT", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var children []models.Menu
+	db.Where("parent_id = ?", parent.ID).Order("order_index").Find(&children)
+	testutil.AssertLen(t, children, 3)
+	testutil.AssertEqual(t, middle.ID, children[0].ID, "Middle should move to the front")
+	testutil.AssertEqual(t, first.ID, children[1].ID)
+	testutil.AssertEqual(t, last.ID, children[2].ID)
+	for i, child := range children {
+		testutil.AssertEqual(t, i, child.OrderIndex)
+	}
+}
+
+func TestUpdateMenu_TitleKeepsParent(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent := testutil.CreateMenuFixture(db, "Parent", nil, 0)
+	child := testutil.CreateMenuFixture(db, "Child", &parent.ID, 0)
+
+	reqBody := dto.UpdateMenuRequest{
+		Title: stringPtr("Renamed Child"),
+	}
+
+	body, _ := json.Marshal(reqBody)
+	url := fmt.Sprintf("/api/menus/%s", child.ID)
+	req := httptest.NewRequest("PUT", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	menuData := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, "Renamed Child", menuData["title"])
+	testutil.AssertEqual(t, parent.ID.String(), menuData["parent_id"], "Parent should be untouched")
+}
+
+func TestUpdateMenu_NullTitle(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	menu := testutil.CreateMenuFixture(db, "Menu", nil, 0)
+
+	body := []byte(`{"title": null}`)
+	url := fmt.Sprintf("/api/menus/%s", menu.ID)
+	req := httptest.NewRequest("PUT", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertContains(t, result.Error, "title cannot be null")
+}
+
+func TestReorderSiblings_Success(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent, children := testutil.CreateMenuHierarchy(db)
+
+	reqBody := dto.ReorderSiblingsRequest{
+		ParentID:   &parent.ID,
+		OrderedIDs: []uuid.UUID{children[2].ID, children[0].ID, children[1].ID},
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("PATCH", "/api/menus/reorder-batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, "Menus reordered successfully", result.Message)
+
+	siblings := result.Data.([]interface{})
+	testutil.AssertLen(t, siblings, 3)
+	for i, id := range reqBody.OrderedIDs {
+		sibling := siblings[i].(map[string]interface{})
+		testutil.AssertEqual(t, id.String(), sibling["id"])
+		testutil.AssertEqual(t, float64(i), sibling["order_index"])
+	}
+}
+
+func TestReorderSiblings_MismatchedSet(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent, children := testutil.CreateMenuHierarchy(db)
+	stranger := testutil.CreateMenuFixture(db, "Stranger", nil, 1)
+
+	reqBody := dto.ReorderSiblingsRequest{
+		ParentID:   &parent.ID,
+		OrderedIDs: []uuid.UUID{children[2].ID, children[0].ID, stranger.ID},
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("PATCH", "/api/menus/reorder-batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertContains(t, result.Error, "must match the current children")
+
+	var child models.Menu
+	db.Where("id = ?", children[0].ID).First(&child)
+	testutil.AssertEqual(t, 0, child.OrderIndex, "Indices should be unchanged")
+}
+
+func TestDeleteMenu_SoftDeletesSubtree(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	hierarchy := testutil.CreateMultiLevelHierarchy(db)
+
+	url := fmt.Sprintf("/api/menus/%s", hierarchy["root1"].ID)
+	resp, err := app.Test(httptest.NewRequest("DELETE", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var visible int64
+	db.Model(&models.Menu{}).Count(&visible)
+	testutil.AssertEqual(t, int64(1), visible, "Only Root 2 should remain visible")
+
+	var stored int64
+	db.Unscoped().Model(&models.Menu{}).Count(&stored)
+	testutil.AssertEqual(t, int64(5), stored, "Soft-deleted rows should still be stored")
+}
+
+func TestGetMenus_IncludeDeleted(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent, _ := testutil.CreateMenuHierarchy(db)
+	db.Delete(&models.Menu{}, "id = ?", parent.ID)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/menus", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	testutil.AssertLen(t, result.Data.([]interface{}), 0, "Deleted menus should be hidden by default")
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/menus?include_deleted=true", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	menus := result.Data.([]interface{})
+	testutil.AssertLen(t, menus, 1)
+	testutil.AssertNotNil(t, menus[0].(map[string]interface{})["deleted_at"])
+}
+
+func TestRestoreMenu_RoundTrip(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	hierarchy := testutil.CreateMultiLevelHierarchy(db)
+
+	url := fmt.Sprintf("/api/menus/%s", hierarchy["root1"].ID)
+	if _, err := app.Test(httptest.NewRequest("DELETE", url, nil)); err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	url = fmt.Sprintf("/api/menus/%s/restore", hierarchy["root1"].ID)
+	resp, err := app.Test(httptest.NewRequest("POST", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, "Menu restored successfully", result.Message)
+
+	var visible int64
+	db.Model(&models.Menu{}).Count(&visible)
+	testutil.AssertEqual(t, int64(5), visible, "Whole subtree should be restored")
+}
+
+func TestRestoreMenu_NotDeleted(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	menu := testutil.CreateMenuFixture(db, "Alive", nil, 0)
+
+	url := fmt.Sprintf("/api/menus/%s/restore", menu.ID)
+	resp, err := app.Test(httptest.NewRequest("POST", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusNotFound, resp)
+}
+
+func TestRestoreMenu_ParentStillDeleted(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent, children := testutil.CreateMenuHierarchy(db)
+	db.Delete(&models.Menu{}, "id IN ?", []uuid.UUID{parent.ID, children[0].ID})
+
+	url := fmt.Sprintf("/api/menus/%s/restore", children[0].ID)
+	resp, err := app.Test(httptest.NewRequest("POST", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+}
+
+func TestCloneMenu_Success(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	hierarchy := testutil.CreateMultiLevelHierarchy(db)
+
+	url := fmt.Sprintf("/api/menus/%s/clone", hierarchy["root1"].ID)
+	resp, err := app.Test(httptest.NewRequest("POST", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, "Menu cloned successfully", result.Message)
+
+	clone := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, "Root 1 (copy)", clone["title"])
+	testutil.AssertNotEqual(t, hierarchy["root1"].ID.String(), clone["id"])
+	testutil.AssertEqual(t, float64(2), clone["order_index"], "Clone should be appended after Root 2")
+	testutil.AssertEqual(t, fmt.Sprintf("/api/menus/%s", clone["id"]), resp.Header.Get(fiber.HeaderLocation))
+
+	children := clone["children"].([]interface{})
+	testutil.AssertLen(t, children, 2)
+	first := children[0].(map[string]interface{})
+	testutil.AssertEqual(t, "Child 1.1", first["title"])
+	testutil.AssertNotEqual(t, hierarchy["child1_1"].ID.String(), first["id"])
+	testutil.AssertLen(t, first["children"].([]interface{}), 1)
+	testutil.AssertEqual(t, "Child 1.2", children[1].(map[string]interface{})["title"])
+
+	var count int64
+	db.Model(&models.Menu{}).Count(&count)
+	testutil.AssertEqual(t, int64(9), count)
+}
+
+func TestCloneMenu_UnderNewParent(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent, _ := testutil.CreateMenuHierarchy(db)
+	target := testutil.CreateMenuFixture(db, "Target", nil, 1)
+	testutil.CreateMenuFixture(db, "Existing", &target.ID, 0)
+
+	body, _ := json.Marshal(dto.CloneMenuRequest{ParentID: &target.ID})
+	url := fmt.Sprintf("/api/menus/%s/clone", parent.ID)
+	req := httptest.NewRequest("POST", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	clone := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, target.ID.String(), clone["parent_id"])
+	testutil.AssertEqual(t, float64(1), clone["order_index"])
+}
+
+func TestCloneMenu_NotFound(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	url := fmt.Sprintf("/api/menus/%s/clone", uuid.New())
+	resp, err := app.Test(httptest.NewRequest("POST", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusNotFound, resp)
+}
+
+func TestCreateMenu_Inactive(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	reqBody := dto.CreateMenuRequest{
+		Title:    "Hidden",
+		IsActive: boolPtr(false),
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/api/menus", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+
+	var stored models.Menu
+	db.Where("title = ?", "Hidden").First(&stored)
+	testutil.AssertEqual(t, false, stored.IsActive, "Explicit is_active=false should be stored")
+}
+
+func TestGetMenus_ActiveOnlyPrunesSubtree(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	hierarchy := testutil.CreateMultiLevelHierarchy(db)
+	db.Model(&models.Menu{}).Where("id = ?", hierarchy["child1_1"].ID).Update("is_active", false)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/menus?active_only=true", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	menus := result.Data.([]interface{})
+	testutil.AssertLen(t, menus, 2)
+	children := menus[0].(map[string]interface{})["children"].([]interface{})
+	testutil.AssertLen(t, children, 1, "Inactive Child 1.1 and its grandchild should be pruned")
+	testutil.AssertEqual(t, "Child 1.2", children[0].(map[string]interface{})["title"])
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/menus", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	children = result.Data.([]interface{})[0].(map[string]interface{})["children"].([]interface{})
+	testutil.AssertLen(t, children, 2, "Inactive menus are still listed by default")
+}
+
+func TestToggleMenu_HidesDescendants(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent, _ := testutil.CreateMenuHierarchy(db)
+
+	url := fmt.Sprintf("/api/menus/%s/toggle", parent.ID)
+	resp, err := app.Test(httptest.NewRequest("PATCH", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, false, result.Data.(map[string]interface{})["is_active"])
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/menus?active_only=true", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	testutil.AssertLen(t, result.Data.([]interface{}), 0)
+
+	resp, err = app.Test(httptest.NewRequest("PATCH", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	testutil.AssertEqual(t, true, result.Data.(map[string]interface{})["is_active"])
+}
+
+func TestToggleMenu_NotFound(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	url := fmt.Sprintf("/api/menus/%s/toggle", uuid.New())
+	resp, err := app.Test(httptest.NewRequest("PATCH", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusNotFound, resp)
+}
+
+func TestUpdateMenu_Deactivate(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	menu := testutil.CreateMenuFixture(db, "Menu", nil, 0)
+
+	url := fmt.Sprintf("/api/menus/%s", menu.ID)
+	body := []byte(`{"is_active": false}`)
+	req := httptest.NewRequest("PUT", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var stored models.Menu
+	db.Where("id = ?", menu.ID).First(&stored)
+	testutil.AssertEqual(t, false, stored.IsActive)
+	testutil.AssertEqual(t, "Menu", stored.Title)
+}
+
+func TestCreateMenu_DuplicatePath(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+
+	reqBody := dto.CreateMenuRequest{
+		Title: "Another Dashboard",
+		Path:  stringPtr("/dashboard"),
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/api/menus", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusConflict, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, "menu path already in use", result.Error)
+}
+
+func TestCreateMenu_PathOfDeletedMenu(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	deleted := testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+	db.Delete(deleted)
+
+	reqBody := dto.CreateMenuRequest{
+		Title: "New Dashboard",
+		Path:  stringPtr("/dashboard"),
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/api/menus", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+}
+
+func TestUpdateMenu_PathOwnedByAnother(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+	menu := testutil.CreateMenuWithPath(db, "Settings", "/settings", "icon-settings", nil)
+
+	body := []byte(`{"path": "/dashboard"}`)
+	url := fmt.Sprintf("/api/menus/%s", menu.ID)
+	req := httptest.NewRequest("PUT", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusConflict, resp)
+
+	var stored models.Menu
+	db.Where("id = ?", menu.ID).First(&stored)
+	testutil.AssertEqual(t, "/settings", *stored.Path)
+}
+
+func TestUpdateMenu_KeepOwnPath(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	menu := testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+
+	body := []byte(`{"title": "Home", "path": "/dashboard"}`)
+	url := fmt.Sprintf("/api/menus/%s", menu.ID)
+	req := httptest.NewRequest("PUT", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+}
+
+func TestRestoreMenu_PathTakenMeanwhile(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	deleted := testutil.CreateMenuWithPath(db, "Dashboard", "/dashboard", "icon-dashboard", nil)
+	db.Delete(deleted)
+	testutil.CreateMenuWithPath(db, "New Dashboard", "/dashboard", "icon-dashboard", nil)
+
+	url := fmt.Sprintf("/api/menus/%s/restore", deleted.ID)
+	resp, err := app.Test(httptest.NewRequest("POST", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusConflict, resp)
+}
+
+func TestRestoreMenu_AppendsAfterSiblingsCreatedMeanwhile(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	parent, children := testutil.CreateMenuHierarchy(db)
+
+	url := fmt.Sprintf("/api/menus/%s", children[1].ID)
+	if _, err := app.Test(httptest.NewRequest("DELETE", url, nil)); err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+	testutil.AssertEqual(t, []string{"Child 1", "Child 3"}, groupTitles(t, db, "parent_id = ?", parent.ID))
+
+	body, _ := json.Marshal(dto.CreateMenuRequest{Title: "Child 4", ParentID: &parent.ID, OrderIndex: intPtr(1)})
+	req := httptest.NewRequest("POST", "/api/menus", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+
+	url = fmt.Sprintf("/api/menus/%s/restore", children[1].ID)
+	resp, err = app.Test(httptest.NewRequest("POST", url, nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	testutil.AssertEqual(t, []string{"Child 1", "Child 4", "Child 3", "Child 2"}, groupTitles(t, db, "parent_id = ?", parent.ID))
+}
+
+func TestGetMenuSelectOptions_PreOrder(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMultiLevelHierarchy(db)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/menus/select-options", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result struct {
+		Data []models.MenuSelectOption `json:"data"`
+	}
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	expected := []struct {
+		title string
+		depth int
+	}{
+		{"Root 1", 0},
+		{"Child 1.1", 1},
+		{"Grandchild 1.1.1", 2},
+		{"Child 1.2", 1},
+		{"Root 2", 0},
+	}
+
+	testutil.AssertLen(t, result.Data, len(expected))
+	for i, want := range expected {
+		testutil.AssertEqual(t, want.title, result.Data[i].Title)
+		testutil.AssertEqual(t, want.depth, result.Data[i].Depth)
+	}
+}
+
+func TestGetMenuSelectOptions_MaxDepth(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMultiLevelHierarchy(db)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/menus/select-options?max_depth=2", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	var result struct {
+		Data []models.MenuSelectOption `json:"data"`
+	}
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertLen(t, result.Data, 4, "Grandchild should be excluded")
+	for _, option := range result.Data {
+		testutil.AssertNotEqual(t, 2, option.Depth)
+	}
+}
+
+func setupAuthenticatedTest(t *testing.T, userID uint) (*fiber.App, *gorm.DB, func()) {
+	_, db, cleanup := setupTest(t)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", userID)
+		return c.Next()
+	})
+	routes.SetupRoutes(app)
+
+	return app, db, cleanup
+}
+
+func TestCreateMenu_RecordsCreator(t *testing.T) {
+	app, db, cleanup := setupAuthenticatedTest(t, 7)
+	defer cleanup()
+
+	body, _ := json.Marshal(dto.CreateMenuRequest{Title: "Audited"})
+	req := httptest.NewRequest("POST", "/api/menus", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	menuData := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, float64(7), menuData["created_by"])
+	testutil.AssertEqual(t, float64(7), menuData["updated_by"])
+
+	var stored models.Menu
+	db.Where("title = ?", "Audited").First(&stored)
+	testutil.AssertEqual(t, uint(7), *stored.CreatedBy)
+}
+
+func TestMoveMenu_RecordsEditor(t *testing.T) {
+	app, db, cleanup := setupAuthenticatedTest(t, 9)
+	defer cleanup()
+
+	parent := testutil.CreateMenuFixture(db, "Parent", nil, 0)
+	child := testutil.CreateMenuFixture(db, "Child", nil, 1)
+
+	body, _ := json.Marshal(dto.MoveMenuRequest{ParentID: &parent.ID})
+	url := fmt.Sprintf("/api/menus/%s/move", child.ID)
+	req := httptest.NewRequest("PATCH", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var stored models.Menu
+	db.Where("id = ?", child.ID).First(&stored)
+	testutil.AssertNil(t, stored.CreatedBy)
+	testutil.AssertEqual(t, uint(9), *stored.UpdatedBy)
+}
+
+func TestCloneMenu_RecordsCreator(t *testing.T) {
+	app, db, cleanup := setupAuthenticatedTest(t, 5)
+	defer cleanup()
+
+	parent, _ := testutil.CreateMenuHierarchy(db)
+
+	url := fmt.Sprintf("/api/menus/%s/clone", parent.ID)
+	resp, err := app.Test(httptest.NewRequest("POST", url, nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+
+	var clones []models.Menu
+	db.Where("created_by IS NOT NULL").Find(&clones)
+	testutil.AssertLen(t, clones, 4, "The clone and its descendants should record the creator")
+	for _, clone := range clones {
+		testutil.AssertEqual(t, uint(5), *clone.CreatedBy)
+		testutil.AssertEqual(t, uint(5), *clone.UpdatedBy)
+	}
+}
+
+func TestReorderSiblings_RecordsEditor(t *testing.T) {
+	app, db, cleanup := setupAuthenticatedTest(t, 9)
+	defer cleanup()
+
+	parent, children := testutil.CreateMenuHierarchy(db)
+
+	body, _ := json.Marshal(dto.ReorderSiblingsRequest{
+		ParentID:   &parent.ID,
+		OrderedIDs: []uuid.UUID{children[1].ID, children[0].ID, children[2].ID},
+	})
+	req := httptest.NewRequest("PATCH", "/api/menus/reorder-batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var stored models.Menu
+	db.Where("id = ?", children[1].ID).First(&stored)
+	testutil.AssertEqual(t, uint(9), *stored.UpdatedBy)
+}
+
+func TestUpdateMenu_AnonymousKeepsEditor(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	editor := uint(3)
+	menu := testutil.CreateMenuFixture(db, "Menu", nil, 0)
+	db.Model(menu).Update("updated_by", editor)
+
+	body := []byte(`{"title": "Renamed"}`)
+	url := fmt.Sprintf("/api/menus/%s", menu.ID)
+	req := httptest.NewRequest("PUT", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var stored models.Menu
+	db.Where("id = ?", menu.ID).First(&stored)
+	testutil.AssertEqual(t, editor, *stored.UpdatedBy)
+}
+
+func TestReorderMenu_NotFound(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	body, _ := json.Marshal(dto.ReorderMenuRequest{NewIndex: 0})
+	url := fmt.Sprintf("/api/menus/%s/reorder", uuid.New())
+	req := httptest.NewRequest("PATCH", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusNotFound, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, models.CodeMenuNotFound, result.Code)
+}
+
+func TestReorderMenu_StaleOldIndex(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuFixture(db, "Menu 0", nil, 0)
+	testutil.CreateMenuFixture(db, "Menu 1", nil, 1)
+	menu := testutil.CreateMenuFixture(db, "Menu 2", nil, 2)
+
+	// The client still believes the menu is at index 1
+	body, _ := json.Marshal(dto.ReorderMenuRequest{NewIndex: 0, OldIndex: intPtr(1)})
+	url := fmt.Sprintf("/api/menus/%s/reorder", menu.ID)
+	req := httptest.NewRequest("PATCH", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusConflict, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, models.CodeStaleOldIndex, result.Code)
+	testutil.AssertEqual(t, []string{"Menu 0", "Menu 1", "Menu 2"}, groupTitles(t, db, "parent_id IS NULL"))
+}
+
+func TestReorderMenu_OldIndexOutOfRange(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuFixture(db, "Menu 0", nil, 0)
+	menu := testutil.CreateMenuFixture(db, "Menu 1", nil, 1)
+
+	reqBody := dto.ReorderMenuRequest{
+		NewIndex: 0,
+		OldIndex: intPtr(5),
+	}
+
+	body, _ := json.Marshal(reqBody)
+	url := fmt.Sprintf("/api/menus/%s/reorder", menu.ID)
+	req := httptest.NewRequest("PATCH", url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertContains(t, result.Error, "old_index is outside")
+
+	var stored models.Menu
+	db.Where("id = ?", menu.ID).First(&stored)
+	testutil.AssertEqual(t, 1, stored.OrderIndex, "Order should be untouched")
+}
+
+func TestListMenus_SearchAndPaginate(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	testutil.CreateMultiLevelHierarchy(db)
+	testutil.CreateMenuWithPath(db, "Settings", "/root-settings", "icon-settings", nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/menus/list?search=ROOT&sort=title&limit=2", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.PaginatedResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, int64(3), result.Total, "Root 1, Root 2 and Settings (by path) should match")
+	menus := result.Data.([]interface{})
+	testutil.AssertLen(t, menus, 2)
+	testutil.AssertEqual(t, "Root 1", menus[0].(map[string]interface{})["title"])
+	testutil.AssertEqual(t, "/api/menus/list?limit=2&page=2&search=ROOT&sort=title", result.Links.Next)
+	testutil.AssertEqual(t, 2, result.TotalPages)
+	testutil.AssertEqual(t, true, result.HasNext)
+	testutil.AssertEqual(t, false, result.HasPrev)
+}
+
+func TestListMenus_InvalidSort(t *testing.T) {
+	app, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/menus/list?sort=deleted_at", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+}
+
+func TestGetMenuOrphans(t *testing.T) {
+	app, db, cleanup := setupAdminTest(t)
+	defer cleanup()
+
+	testutil.CreateMenuHierarchy(db)
+	missingParent := uuid.New()
+	orphan := testutil.CreateMenuFixture(db, "Orphan", &missingParent, 0)
+
+	resp, err := app.Test(adminRequest("GET", "/api/menus/orphans", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	orphans := result.Data.([]interface{})
+	testutil.AssertLen(t, orphans, 1)
+	testutil.AssertEqual(t, orphan.ID.String(), orphans[0].(map[string]interface{})["id"])
+}
+
+func TestAssignMenuIcons_Success(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	first := testutil.CreateMenuFixture(db, "First", nil, 0)
+	second := testutil.CreateMenuWithPath(db, "Second", "/second", "icon-old", nil)
+
+	reqBody := dto.AssignMenuIconsRequest{
+		Assignments: map[uuid.UUID]string{
+			first.ID:  "icon-a",
+			second.ID: "icon-b",
+		},
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("PATCH", "/api/menus/icons", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	data := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, float64(2), data["updated"])
+	testutil.AssertLen(t, data["unknown_ids"].([]interface{}), 0)
+
+	var stored models.Menu
+	db.Where("id = ?", second.ID).First(&stored)
+	testutil.AssertEqual(t, "icon-b", *stored.Icon)
+	testutil.AssertEqual(t, "/second", *stored.Path)
+}
+
+func TestAssignMenuIcons_UnknownID(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	menu := testutil.CreateMenuFixture(db, "Menu", nil, 0)
+	unknownID := uuid.New()
+
+	reqBody := dto.AssignMenuIconsRequest{
+		Assignments: map[uuid.UUID]string{
+			menu.ID:   "icon-a",
+			unknownID: "icon-b",
+		},
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("PATCH", "/api/menus/icons", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	data := result.Data.(map[string]interface{})
+	testutil.AssertEqual(t, float64(1), data["updated"])
+	unknown := data["unknown_ids"].([]interface{})
+	testutil.AssertLen(t, unknown, 1)
+	testutil.AssertEqual(t, unknownID.String(), unknown[0])
+
+	var stored models.Menu
+	db.Where("id = ?", menu.ID).First(&stored)
+	testutil.AssertEqual(t, "icon-a", *stored.Icon)
+}
+
+func TestAssignMenuIcons_IconTooLong(t *testing.T) {
+	app, db, cleanup := setupTest(t)
+	defer cleanup()
+
+	menu := testutil.CreateMenuFixture(db, "Menu", nil, 0)
+
+	reqBody := dto.AssignMenuIconsRequest{
+		Assignments: map[uuid.UUID]string{
+			menu.ID: strings.Repeat("i", 101),
+		},
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("PATCH", "/api/menus/icons", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusBadRequest, resp)
+}
+
+func validateTree(t *testing.T, app *fiber.App) models.MenuTreeReport {
+	t.Helper()
+
+	resp, err := app.Test(adminRequest("GET", "/api/menus/validate", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	var result struct {
+		Data models.MenuTreeReport `json:"data"`
+	}
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	return result.Data
+}
+
+func TestValidateMenuTree_Valid(t *testing.T) {
+	app, db, cleanup := setupAdminTest(t)
+	defer cleanup()
+
+	testutil.CreateMultiLevelHierarchy(db)
+
+	report := validateTree(t, app)
+
+	testutil.AssertEqual(t, true, report.Valid)
+	testutil.AssertLen(t, report.Violations, 0)
+}
+
+func TestValidateMenuTree_GapAndDuplicatePath(t *testing.T) {
+	app, db, cleanup := setupAdminTest(t)
+	defer cleanup()
+
+	// The unique index would otherwise reject the duplicate we want to detect
+	db.Exec("DROP INDEX idx_menus_path_unique")
+
+	parent := testutil.CreateMenuFixture(db, "Parent", nil, 0)
+	first := testutil.CreateMenuFixture(db, "First", &parent.ID, 0)
+	gapped := testutil.CreateMenuFixture(db, "Gapped", &parent.ID, 2)
+	dupA := testutil.CreateMenuWithPath(db, "Docs", "/docs", "icon-docs", nil)
+	dupB := testutil.CreateMenuWithPath(db, "Docs Again", "/docs", "icon-docs", nil)
+	db.Model(dupA).Update("order_index", 1)
+	db.Model(dupB).Update("order_index", 2)
+
+	report := validateTree(t, app)
+
+	testutil.AssertEqual(t, false, report.Valid)
+	testutil.AssertLen(t, report.Violations, 2)
+
+	rules := make(map[string]models.MenuTreeViolation)
+	for _, violation := range report.Violations {
+		rules[violation.Rule] = violation
+	}
+
+	gap, ok := rules["sibling_gap"]
+	if !ok {
+		t.Fatalf("Expected a sibling_gap violation, got %+v", report.Violations)
+	}
+	testutil.AssertEqual(t, []uuid.UUID{first.ID, gapped.ID}, gap.MenuIDs)
+
+	duplicate, ok := rules["duplicate_path"]
+	if !ok {
+		t.Fatalf("Expected a duplicate_path violation, got %+v", report.Violations)
+	}
+	testutil.AssertEqual(t, []uuid.UUID{dupA.ID, dupB.ID}, duplicate.MenuIDs)
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+var metricsHandler = adaptor.HTTPHandler(promhttp.Handler())
+
+// Metrics godoc
+// @Summary      Prometheus Metrics
+// @Description  Expose request, latency and database pool metrics in the Prometheus text format
+// @Tags         Health
+// @Produce      plain
+// @Success      200  {string}  string
+// @Router       /metrics [get]
+func Metrics(c *fiber.Ctx) error {
+	return metricsHandler(c)
+}
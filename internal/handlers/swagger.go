@@ -0,0 +1,13 @@
+package handlers
+
+import (
+	"github.com/andhikadk/stk-test-be/docs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SwaggerJSON serves the OpenAPI document embedded at build time
+func SwaggerJSON(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(docs.SwaggerJSON)
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	pkgutils "github.com/andhikadk/stk-test-be/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderAdminToken carries the static admin token on admin-only endpoints
+const HeaderAdminToken = "X-Admin-Token"
+
+// AdminTokenMiddleware only lets requests through whose X-Admin-Token header
+// equals token. An empty token disables the endpoints it guards, so they
+// answer 403 until an admin token is configured.
+func AdminTokenMiddleware(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token == "" {
+			return pkgutils.ForbiddenResponse(c, "admin endpoints are disabled: no admin token is configured")
+		}
+
+		if subtle.ConstantTimeCompare([]byte(c.Get(HeaderAdminToken)), []byte(token)) != 1 {
+			return pkgutils.UnauthorizedResponse(c, "missing or invalid admin token")
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func adminApp(token string) *fiber.App {
+	app := fiber.New()
+	app.Get("/admin", middleware.AdminTokenMiddleware(token), func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	return app
+}
+
+func TestAdminTokenMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		sent       string
+		status     int
+	}{
+		{"matching token", "secret-token", "secret-token", fiber.StatusOK},
+		{"wrong token", "secret-token", "other-token", fiber.StatusUnauthorized},
+		{"missing token", "secret-token", "", fiber.StatusUnauthorized},
+		{"not configured", "", "", fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/admin", nil)
+			if tt.sent != "" {
+				req.Header.Set(middleware.HeaderAdminToken, tt.sent)
+			}
+
+			resp, err := adminApp(tt.configured).Test(req)
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			testutil.AssertStatusCode(t, tt.status, resp)
+			if tt.status != fiber.StatusOK {
+				var result models.APIResponse
+				testutil.ParseJSONResponse(t, resp.Body, &result)
+				testutil.AssertEqual(t, models.CodeForStatus(tt.status), result.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+// CORSMiddleware allows cross-origin requests from the given origin
+// patterns (see MatchOrigin); a "*" pattern allows every origin
+func CORSMiddleware(origins []string, methods, headers string, credentials bool) fiber.Handler {
+	cfg := cors.Config{
+		AllowMethods:     methods,
+		AllowHeaders:     headers,
+		AllowCredentials: credentials,
+	}
+
+	if slices.Contains(origins, "*") {
+		cfg.AllowOrigins = "*"
+	} else {
+		cfg.AllowOriginsFunc = func(origin string) bool {
+			return MatchOrigin(origins, origin)
+		}
+	}
+
+	return cors.New(cfg)
+}
+
+// MatchOrigin reports whether origin matches one of the patterns. A pattern
+// is an exact origin ("https://app.example.com") or has a "*." wildcard
+// matching any subdomain, at any depth, of the rest ("https://*.example.com"
+// allows "https://a.b.example.com" but not "https://example.com"). Patterns
+// without a scheme ("*.example.com") match the host of any scheme.
+func MatchOrigin(patterns []string, origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, pattern := range patterns {
+		if matchOrigin(strings.TrimSuffix(strings.ToLower(pattern), "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchOrigin(pattern, origin string) bool {
+	if !strings.Contains(pattern, "://") {
+		if i := strings.Index(origin, "://"); i != -1 {
+			origin = origin[i+3:]
+		}
+	}
+
+	i := strings.Index(pattern, "*.")
+	if i == -1 {
+		return pattern == origin
+	}
+
+	prefix, suffix := pattern[:i], pattern[i+1:]
+	if !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+		return false
+	}
+
+	subdomain := origin[len(prefix) : len(origin)-len(suffix)]
+	return subdomain != "" && strings.Trim(subdomain, "abcdefghijklmnopqrstuvwxyz0123456789-.") == ""
+}
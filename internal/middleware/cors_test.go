@@ -0,0 +1,64 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.CORSMiddleware(
+		[]string{"http://localhost:3000", "https://*.example.com"},
+		"GET,POST",
+		"Content-Type",
+		true,
+	))
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{origin: "http://localhost:3000", allowed: true},
+		{origin: "https://tenant-a.example.com", allowed: true},
+		{origin: "https://eu.tenant-b.example.com", allowed: true},
+		{origin: "https://example.com", allowed: false},
+		{origin: "http://tenant-a.example.com", allowed: false},
+		{origin: "https://tenant-a.example.com.evil.io", allowed: false},
+		{origin: "https://unlisted.io", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/ok", nil)
+			req.Header.Set(fiber.HeaderOrigin, tt.origin)
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			got := resp.Header.Get(fiber.HeaderAccessControlAllowOrigin)
+			if tt.allowed {
+				testutil.AssertEqual(t, tt.origin, got)
+			} else {
+				testutil.AssertEmpty(t, got)
+			}
+		})
+	}
+}
+
+func TestMatchOrigin_SchemelessPattern(t *testing.T) {
+	patterns := []string{"*.example.com"}
+
+	testutil.AssertEqual(t, true, middleware.MatchOrigin(patterns, "http://app.example.com"))
+	testutil.AssertEqual(t, true, middleware.MatchOrigin(patterns, "https://app.example.com"))
+	testutil.AssertEqual(t, false, middleware.MatchOrigin(patterns, "https://example.com"))
+}
@@ -1,7 +1,11 @@
 package middleware
 
 import (
+	"errors"
+
+	"github.com/andhikadk/stk-test-be/internal/dto"
 	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -21,26 +25,45 @@ func ErrorHandlingMiddleware() fiber.Handler {
 	}
 }
 
+// ErrorHandler writes err as an APIResponse; pass it as
+// fiber.Config.ErrorHandler so errors raised outside the middleware chain get
+// the same shape
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	return handleError(c, err)
+}
+
 // handleError processes different types of errors
 func handleError(c *fiber.Ctx, err error) error {
-	var code int
+	var status int
+	var code string
 	var message string
 
-	// Check if it's a Fiber error
-	if e, ok := err.(*fiber.Error); ok {
-		code = e.Code
-		message = e.Message
-	} else {
+	var bindErr *utils.BindError
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &bindErr):
+		// Request body errors from utils.BindAndValidate
+		status = bindErr.Status
+		code = bindErr.Code
+		message = bindErr.Message
+	case errors.As(err, &fiberErr):
+		status = fiberErr.Code
+		code = models.CodeForStatus(status)
+		message = fiberErr.Message
+	default:
 		// Generic error
-		code = fiber.StatusInternalServerError
+		status = fiber.StatusInternalServerError
+		code = models.CodeInternalError
 		message = "Internal Server Error"
 	}
 
 	response := models.APIResponse{
-		Status:  code,
+		Status:  status,
 		Message: message,
+		Code:    code,
 		Error:   err.Error(),
+		Errors:  dto.FieldErrors(err),
 	}
 
-	return c.Status(code).JSON(response)
+	return c.Status(status).JSON(response)
 }
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderIdempotencyKey is the request header naming a retry-safe request
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// HeaderIdempotentReplayed marks a response replayed for a repeated key
+const HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+type idempotentResponse struct {
+	bodyHash  [sha256.Size]byte
+	done      bool
+	status    int
+	body      []byte
+	headers   map[string]string
+	expiresAt time.Time
+}
+
+// replayedHeaders are the response headers stored and replayed with the body
+var replayedHeaders = []string{fiber.HeaderContentType, fiber.HeaderLocation}
+
+// IdempotencyMiddleware makes requests carrying an Idempotency-Key safe to
+// retry: the first response for a key on a route and query string is kept
+// for ttl and replayed for later requests with the same key and body. Reusing the key
+// with a different body gets a 422, and a retry while the first request is
+// still running a 409. 5xx responses are not kept, so those can be retried.
+// Requests without the header pass through.
+func IdempotencyMiddleware(ttl time.Duration) fiber.Handler {
+	var mu sync.Mutex
+	responses := make(map[string]*idempotentResponse)
+	lastSweep := time.Now()
+
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return c.Next()
+		}
+
+		now := time.Now()
+		storeKey := c.Method() + " " + c.Path() + "?" + string(c.Request().URI().QueryString()) + " " + key
+		bodyHash := sha256.Sum256(c.Body())
+
+		mu.Lock()
+		// Drop expired responses now and then so old keys don't pile up
+		if now.Sub(lastSweep) >= ttl {
+			for k, r := range responses {
+				if r.done && now.After(r.expiresAt) {
+					delete(responses, k)
+				}
+			}
+			lastSweep = now
+		}
+
+		stored, ok := responses[storeKey]
+		if ok && stored.done && now.After(stored.expiresAt) {
+			ok = false
+		}
+		if !ok {
+			responses[storeKey] = &idempotentResponse{bodyHash: bodyHash}
+		}
+		mu.Unlock()
+
+		if ok {
+			switch {
+			case stored.bodyHash != bodyHash:
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(models.APIResponse{
+					Status:  fiber.StatusUnprocessableEntity,
+					Message: "Idempotency key reused",
+					Code:    models.CodeIdempotencyKeyReused,
+					Error:   "this Idempotency-Key was already used with a different request body",
+				})
+			case !stored.done:
+				return c.Status(fiber.StatusConflict).JSON(models.APIResponse{
+					Status:  fiber.StatusConflict,
+					Message: "Request in progress",
+					Code:    models.CodeIdempotencyKeyInFlight,
+					Error:   "a request with this Idempotency-Key is still being processed",
+				})
+			}
+
+			for name, value := range stored.headers {
+				c.Set(name, value)
+			}
+			c.Set(HeaderIdempotentReplayed, "true")
+			return c.Status(stored.status).Send(stored.body)
+		}
+
+		err := c.Next()
+		status := c.Response().StatusCode()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Errors returned up the chain have not been written yet, and 5xx
+		// responses may succeed on retry, so neither is kept
+		if err != nil || status >= fiber.StatusInternalServerError {
+			delete(responses, storeKey)
+			return err
+		}
+
+		headers := make(map[string]string, len(replayedHeaders))
+		for _, name := range replayedHeaders {
+			if value := c.GetRespHeader(name); value != "" {
+				headers[name] = value
+			}
+		}
+		responses[storeKey] = &idempotentResponse{
+			bodyHash:  bodyHash,
+			done:      true,
+			status:    status,
+			body:      append([]byte(nil), c.Response().Body()...),
+			headers:   headers,
+			expiresAt: time.Now().Add(ttl),
+		}
+		return nil
+	}
+}
@@ -0,0 +1,114 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func idempotentApp(status int, calls *int) *fiber.App {
+	app := fiber.New()
+	app.Post("/items", middleware.IdempotencyMiddleware(time.Minute), func(c *fiber.Ctx) error {
+		*calls++
+		return c.Status(status).SendString("call " + strconv.Itoa(*calls))
+	})
+	return app
+}
+
+func postItem(t *testing.T, app *fiber.App, key, body string) (*http.Response, string) {
+	t.Helper()
+	return postItemTo(t, app, "/items", key, body)
+}
+
+func postItemTo(t *testing.T, app *fiber.App, target, key, body string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", target, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(middleware.HeaderIdempotencyKey, key)
+	}
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp, string(respBody)
+}
+
+func TestIdempotencyMiddleware_ReplaysResponse(t *testing.T) {
+	calls := 0
+	app := idempotentApp(fiber.StatusCreated, &calls)
+
+	first, firstBody := postItem(t, app, "key-1", `{"a":1}`)
+	second, secondBody := postItem(t, app, "key-1", `{"a":1}`)
+
+	testutil.AssertEqual(t, 1, calls)
+	testutil.AssertStatusCode(t, fiber.StatusCreated, first)
+	testutil.AssertStatusCode(t, fiber.StatusCreated, second)
+	testutil.AssertEqual(t, firstBody, secondBody)
+	testutil.AssertEqual(t, "", first.Header.Get(middleware.HeaderIdempotentReplayed))
+	testutil.AssertEqual(t, "true", second.Header.Get(middleware.HeaderIdempotentReplayed))
+
+	postItem(t, app, "key-2", `{"a":1}`)
+	postItem(t, app, "", `{"a":1}`)
+	testutil.AssertEqual(t, 3, calls, "Other keys and requests without a key should run")
+}
+
+func TestIdempotencyMiddleware_DifferentBody(t *testing.T) {
+	calls := 0
+	app := idempotentApp(fiber.StatusCreated, &calls)
+
+	postItem(t, app, "key-1", `{"a":1}`)
+	resp, body := postItem(t, app, "key-1", `{"a":2}`)
+
+	testutil.AssertEqual(t, 1, calls)
+	testutil.AssertStatusCode(t, fiber.StatusUnprocessableEntity, resp)
+	testutil.AssertContains(t, body, models.CodeIdempotencyKeyReused)
+
+	// The mismatched request does not replace the stored response
+	resp, body = postItem(t, app, "key-1", `{"a":1}`)
+	testutil.AssertEqual(t, 1, calls)
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+	testutil.AssertEqual(t, "call 1", body)
+	testutil.AssertEqual(t, "true", resp.Header.Get(middleware.HeaderIdempotentReplayed))
+}
+
+func TestIdempotencyMiddleware_QueryStringIsPartOfKey(t *testing.T) {
+	calls := 0
+	app := idempotentApp(fiber.StatusCreated, &calls)
+
+	postItemTo(t, app, "/items?dry_run=true", "key-1", `{"a":1}`)
+	resp, body := postItemTo(t, app, "/items?dry_run=false", "key-1", `{"a":1}`)
+
+	testutil.AssertEqual(t, 2, calls, "A different query string should not be replayed")
+	testutil.AssertEqual(t, "call 2", body)
+	testutil.AssertEqual(t, "", resp.Header.Get(middleware.HeaderIdempotentReplayed))
+
+	resp, body = postItemTo(t, app, "/items?dry_run=true", "key-1", `{"a":1}`)
+	testutil.AssertEqual(t, 2, calls)
+	testutil.AssertEqual(t, "call 1", body)
+	testutil.AssertEqual(t, "true", resp.Header.Get(middleware.HeaderIdempotentReplayed))
+}
+
+func TestIdempotencyMiddleware_ServerErrorNotKept(t *testing.T) {
+	calls := 0
+	app := idempotentApp(fiber.StatusInternalServerError, &calls)
+
+	postItem(t, app, "key-1", `{"a":1}`)
+	resp, _ := postItem(t, app, "key-1", `{"a":1}`)
+
+	testutil.AssertEqual(t, 2, calls, "A 5xx should not be replayed")
+	testutil.AssertEqual(t, "", resp.Header.Get(middleware.HeaderIdempotentReplayed))
+}
@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"bufio"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/handlers"
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// scrapeCounter returns the value of the series line starting with series,
+// or 0 when it has not been recorded yet
+func scrapeCounter(t *testing.T, app *fiber.App, series string) float64 {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatalf("Failed to scrape metrics: %v", err)
+	}
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, series+" ") {
+			value, err := strconv.ParseFloat(strings.TrimPrefix(line, series+" "), 64)
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", line, err)
+			}
+			return value
+		}
+	}
+	return 0
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.MetricsMiddleware())
+	app.Get("/metrics", handlers.Metrics)
+	app.Get("/widgets/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	app.Get("/teapot", func(c *fiber.Ctx) error {
+		return fiber.ErrTeapot
+	})
+
+	okSeries := `http_requests_total{method="GET",route="/widgets/:id",status="200"}`
+	teapotSeries := `http_requests_total{method="GET",route="/teapot",status="418"}`
+	okBefore := scrapeCounter(t, app, okSeries)
+	teapotBefore := scrapeCounter(t, app, teapotSeries)
+
+	for _, path := range []string{"/widgets/1", "/widgets/2", "/teapot"} {
+		if _, err := app.Test(httptest.NewRequest("GET", path, nil)); err != nil {
+			t.Fatalf("Failed to perform request: %v", err)
+		}
+	}
+
+	// Both IDs are counted under the route pattern
+	testutil.AssertEqual(t, okBefore+2, scrapeCounter(t, app, okSeries))
+	testutil.AssertEqual(t, teapotBefore+1, scrapeCounter(t, app, teapotSeries))
+}
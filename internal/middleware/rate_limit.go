@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+
+	pkgutils "github.com/andhikadk/stk-test-be/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimitMiddleware allows each client IP at most max requests per fixed
+// window and answers the rest with 429 and a Retry-After header counting
+// down to the start of the next window
+func RateLimitMiddleware(max int, window time.Duration) fiber.Handler {
+	var mu sync.Mutex
+	windows := make(map[string]*rateWindow)
+	lastSweep := time.Now()
+
+	return func(c *fiber.Ctx) error {
+		now := time.Now()
+		key := c.IP()
+
+		mu.Lock()
+		// Drop expired windows now and then so idle clients don't pile up
+		if now.Sub(lastSweep) >= window {
+			for ip, w := range windows {
+				if now.Sub(w.start) >= window {
+					delete(windows, ip)
+				}
+			}
+			lastSweep = now
+		}
+
+		w, ok := windows[key]
+		if !ok || now.Sub(w.start) >= window {
+			w = &rateWindow{start: now}
+			windows[key] = w
+		}
+		w.count++
+		count := w.count
+		retryAfter := w.start.Add(window).Sub(now)
+		mu.Unlock()
+
+		if count > max {
+			return pkgutils.TooManyRequestsResponse(c, retryAfter)
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	const max = 3
+
+	app := fiber.New()
+	app.Use(middleware.RateLimitMiddleware(max, time.Minute))
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	for i := 0; i < max; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/ok", nil))
+		if err != nil {
+			t.Fatalf("Failed to perform request: %v", err)
+		}
+		testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/ok", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+	testutil.AssertStatusCode(t, fiber.StatusTooManyRequests, resp)
+
+	retryAfter, err := strconv.Atoi(resp.Header.Get(fiber.HeaderRetryAfter))
+	testutil.AssertNil(t, err)
+	testutil.AssertEqual(t, true, retryAfter >= 1 && retryAfter <= 60)
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	testutil.AssertEqual(t, fiber.StatusTooManyRequests, result.Status)
+}
+
+func TestRateLimitMiddleware_WindowResets(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.RateLimitMiddleware(1, 50*time.Millisecond))
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	statuses := make([]int, 0, 3)
+	for i := 0; i < 2; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/ok", nil))
+		if err != nil {
+			t.Fatalf("Failed to perform request: %v", err)
+		}
+		statuses = append(statuses, resp.StatusCode)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/ok", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+	statuses = append(statuses, resp.StatusCode)
+
+	testutil.AssertEqual(t, []int{fiber.StatusOK, fiber.StatusTooManyRequests, fiber.StatusOK}, statuses)
+}
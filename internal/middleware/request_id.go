@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/andhikadk/stk-test-be/internal/utils"
+	"github.com/google/uuid"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocalRequestID is the c.Locals key holding the request ID; the access log
+// format reads it as ${locals:requestid}
+const LocalRequestID = "requestid"
+
+// maxRequestIDLength caps client-supplied IDs so they cannot flood the logs
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware reuses the client's X-Request-ID or generates one,
+// echoes it on the response and attaches it to the request context so the
+// utils log helpers include it in every line
+func RequestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(fiber.HeaderXRequestID)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = uuid.NewString()
+		}
+
+		c.Locals(LocalRequestID, id)
+		c.Set(fiber.HeaderXRequestID, id)
+		c.SetUserContext(utils.WithRequestID(c.UserContext(), id))
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,79 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+	"github.com/andhikadk/stk-test-be/internal/utils"
+	"github.com/google/uuid"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequestIDMiddleware_EchoesClientID(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.RequestIDMiddleware())
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals(middleware.LocalRequestID).(string))
+	})
+
+	req := httptest.NewRequest("GET", "/ok", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "client-abc-123")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertEqual(t, "client-abc-123", resp.Header.Get(fiber.HeaderXRequestID))
+
+	body := new(bytes.Buffer)
+	body.ReadFrom(resp.Body)
+	testutil.AssertEqual(t, "client-abc-123", body.String())
+}
+
+func TestRequestIDMiddleware_GeneratesID(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.RequestIDMiddleware())
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/ok", nil))
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	_, err = uuid.Parse(resp.Header.Get(fiber.HeaderXRequestID))
+	testutil.AssertNil(t, err)
+}
+
+func TestRequestIDMiddleware_IncludedInLogs(t *testing.T) {
+	var buf bytes.Buffer
+	original := utils.Logger
+	utils.Logger = utils.NewLogger(&buf, slog.LevelInfo, true)
+	defer func() { utils.Logger = original }()
+
+	app := fiber.New()
+	app.Use(middleware.RequestIDMiddleware())
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		utils.Info(c.UserContext(), "handled")
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest("GET", "/ok", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "trace-42")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a JSON log line, got %q: %v", buf.String(), err)
+	}
+	testutil.AssertEqual(t, "trace-42", entry["request_id"])
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const HeaderResponseTime = "X-Response-Time"
+
+// ResponseTimeMiddleware sets X-Response-Time to the time spent in the rest of
+// the handler chain, formatted as a Go duration (e.g. "1.25ms")
+func ResponseTimeMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		c.Set(HeaderResponseTime, time.Since(start).String())
+		return err
+	}
+}
@@ -0,0 +1,40 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestResponseTimeMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.ResponseTimeMiddleware())
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.ErrTeapot
+	})
+
+	for _, path := range []string{"/ok", "/fail"} {
+		t.Run(path, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			header := resp.Header.Get(middleware.HeaderResponseTime)
+			testutil.AssertNotEmpty(t, header)
+
+			duration, err := time.ParseDuration(header)
+			testutil.AssertNil(t, err)
+			testutil.AssertEqual(t, true, duration >= 0)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	pkgutils "github.com/andhikadk/stk-test-be/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TimeoutMiddleware gives every request a context deadline of d and answers
+// 504 once it has passed. Handlers stop early only where they use
+// c.UserContext() (the menu handlers bind their queries to it); paths
+// starting with one of the exempt prefixes, such as long-lived streams, get
+// no deadline. A zero d disables the middleware.
+//
+// The 504 replaces whatever the handler wrote, keeping only the headers set
+// by middleware that ran before this one (request ID, CORS, security
+// headers). Fiber sends nothing until the handler returns, so the client
+// never sees a partial response, but work the handler finished after the
+// deadline, such as a committed write from a handler that ignores
+// c.UserContext(), is not undone: a 504 means the outcome is unknown, not
+// that nothing happened.
+func TimeoutMiddleware(d time.Duration, exempt ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if d <= 0 || isExempt(c.Path(), exempt) {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), d)
+		defer cancel()
+		c.SetUserContext(ctx)
+		upstream := c.GetRespHeaders()
+
+		err := c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			c.Response().Reset()
+			for key, values := range upstream {
+				for _, value := range values {
+					c.Response().Header.Add(key, value)
+				}
+			}
+			return pkgutils.ErrorResponse(c, fiber.StatusGatewayTimeout, "request timed out")
+		}
+
+		return err
+	}
+}
+
+func isExempt(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func slowHandler(c *fiber.Ctx) error {
+	select {
+	case <-c.UserContext().Done():
+		return c.UserContext().Err()
+	case <-time.After(200 * time.Millisecond):
+		return c.SendString("done")
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.RequestIDMiddleware())
+	app.Use(func(c *fiber.Ctx) error {
+		c.Set("X-Frame-Options", "SAMEORIGIN")
+		return c.Next()
+	})
+	app.Use(middleware.TimeoutMiddleware(20*time.Millisecond, "/stream"))
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		c.Set("ETag", `"stale"`)
+		return slowHandler(c)
+	})
+	app.Get("/stream/slow", slowHandler)
+	app.Get("/fast", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	t.Run("slow handler times out", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/slow", nil))
+		if err != nil {
+			t.Fatalf("Failed to perform request: %v", err)
+		}
+
+		testutil.AssertStatusCode(t, fiber.StatusGatewayTimeout, resp)
+
+		var result models.APIResponse
+		testutil.ParseJSONResponse(t, resp.Body, &result)
+		testutil.AssertEqual(t, "request timed out", result.Message)
+		testutil.AssertEqual(t, models.CodeTimeout, result.Code)
+
+		// Headers from earlier middleware survive; the handler's do not
+		testutil.AssertEqual(t, true, resp.Header.Get(fiber.HeaderXRequestID) != "")
+		testutil.AssertEqual(t, "SAMEORIGIN", resp.Header.Get("X-Frame-Options"))
+		testutil.AssertEqual(t, "", resp.Header.Get("ETag"))
+	})
+
+	t.Run("fast handler is unaffected", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/fast", nil))
+		if err != nil {
+			t.Fatalf("Failed to perform request: %v", err)
+		}
+
+		testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+	})
+
+	t.Run("exempt path has no deadline", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/stream/slow", nil), 1000)
+		if err != nil {
+			t.Fatalf("Failed to perform request: %v", err)
+		}
+
+		testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+	})
+}
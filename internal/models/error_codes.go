@@ -0,0 +1,69 @@
+package models
+
+import "net/http"
+
+// Error codes carried in APIResponse.Code. Clients should branch on these
+// rather than on Message or Error, which are meant for humans and may change.
+const (
+	CodeBadRequest         = "BAD_REQUEST"
+	CodeUnauthorized       = "UNAUTHORIZED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeMethodNotAllowed   = "METHOD_NOT_ALLOWED"
+	CodeConflict           = "CONFLICT"
+	CodeRateLimited        = "RATE_LIMITED"
+	CodePayloadTooLarge    = "PAYLOAD_TOO_LARGE"
+	CodeInternalError      = "INTERNAL_ERROR"
+	CodeTimeout            = "TIMEOUT"
+	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	CodeInvalidID          = "INVALID_ID"
+	CodeInvalidQuery       = "INVALID_QUERY"
+	CodeInvalidRequestBody = "INVALID_REQUEST_BODY"
+	CodeValidationFailed   = "VALIDATION_FAILED"
+
+	CodeIdempotencyKeyReused   = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyKeyInFlight = "IDEMPOTENCY_KEY_IN_FLIGHT"
+
+	CodeMenuNotFound        = "MENU_NOT_FOUND"
+	CodeParentMenuNotFound  = "PARENT_MENU_NOT_FOUND"
+	CodeParentMenuDeleted   = "PARENT_MENU_DELETED"
+	CodeDeletedMenuNotFound = "DELETED_MENU_NOT_FOUND"
+	CodeMenuDepthExceeded   = "MENU_DEPTH_EXCEEDED"
+	CodeMenuCycle           = "MENU_CYCLE"
+	CodeMenuPathTaken       = "MENU_PATH_TAKEN"
+	CodeSiblingSetMismatch  = "SIBLING_SET_MISMATCH"
+	CodeOldIndexOutOfRange  = "OLD_INDEX_OUT_OF_RANGE"
+	CodeStaleOldIndex       = "STALE_OLD_INDEX"
+	CodeInvalidImportMode   = "INVALID_IMPORT_MODE"
+	CodeMenuPresetNotFound  = "MENU_PRESET_NOT_FOUND"
+	CodeMenuPresetNameTaken = "MENU_PRESET_NAME_TAKEN"
+)
+
+// CodeForStatus returns the generic error code for an HTTP status, for
+// responses that have no more specific one
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	default:
+		return CodeInternalError
+	}
+}
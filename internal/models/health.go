@@ -0,0 +1,34 @@
+package models
+
+// ReadinessStatus is the data payload of the readiness endpoint; each
+// dependency is reported as "up" or "down"
+type ReadinessStatus struct {
+	Database string      `json:"database" example:"up"`
+	Pool     DBPoolStats `json:"pool"`
+}
+
+// DBPoolStats is a snapshot of the database connection pool
+type DBPoolStats struct {
+	MaxOpenConnections int    `json:"max_open_connections" example:"25"`
+	OpenConnections    int    `json:"open_connections" example:"3"`
+	InUse              int    `json:"in_use" example:"1"`
+	Idle               int    `json:"idle" example:"2"`
+	WaitCount          int64  `json:"wait_count" example:"0"`
+	WaitDuration       string `json:"wait_duration" example:"0s"`
+}
+
+// BuildInfo identifies the running build; fields are "dev" when the binary
+// was built without version ldflags
+type BuildInfo struct {
+	Version   string `json:"version" example:"v1.2.3"`
+	Commit    string `json:"commit" example:"4f2c9e1"`
+	BuildTime string `json:"build_time" example:"2025-01-01T00:00:00Z"`
+}
+
+// HealthStatus is the data payload of the health endpoint
+type HealthStatus struct {
+	App    string `json:"app" example:"stk-test-be"`
+	Status string `json:"status" example:"healthy"`
+	Env    string `json:"env" example:"production"`
+	BuildInfo
+}
@@ -8,15 +8,19 @@ import (
 )
 
 type Menu struct {
-	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	ParentID   *uuid.UUID `gorm:"type:uuid" json:"parent_id,omitempty"`
-	Title      string     `gorm:"size:255;not null" json:"title" example:"Dashboard"`
-	Path       *string    `gorm:"size:255" json:"path,omitempty" example:"/dashboard"`
-	Icon       *string    `gorm:"size:100" json:"icon,omitempty" example:"icon-dashboard"`
-	OrderIndex int        `gorm:"default:0" json:"order_index" example:"0"`
-	CreatedAt  time.Time  `json:"created_at"`
-	UpdatedAt  time.Time  `json:"updated_at"`
-	Children   []Menu     `gorm:"foreignKey:ParentID" json:"children,omitempty"`
+	ID         uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id" example:"123e4567-e89b-12d3-a456-426614174000" format:"uuid"`
+	ParentID   *uuid.UUID     `gorm:"type:uuid;index" json:"parent_id,omitempty" format:"uuid"`
+	Title      string         `gorm:"size:255;not null" json:"title" example:"Dashboard"`
+	Path       *string        `gorm:"size:255;uniqueIndex:idx_menus_path_unique,where:path IS NOT NULL AND deleted_at IS NULL" json:"path,omitempty" example:"/dashboard"`
+	Icon       *string        `gorm:"size:100" json:"icon,omitempty" example:"icon-dashboard"`
+	OrderIndex int            `gorm:"default:0" json:"order_index" example:"0"`
+	IsActive   bool           `gorm:"default:true" json:"is_active" example:"true"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	CreatedBy  *uint          `json:"created_by" example:"1"`
+	UpdatedBy  *uint          `json:"updated_by" example:"1"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string" format:"date-time"`
+	Children   []Menu         `gorm:"foreignKey:ParentID" json:"children,omitempty"`
 }
 
 func (m *Menu) BeforeCreate(tx *gorm.DB) error {
@@ -25,3 +29,45 @@ func (m *Menu) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// MenuSelectOption is one row of the flattened tree used by select inputs;
+// Depth is 0 for root menus
+type MenuSelectOption struct {
+	ID       uuid.UUID  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000" format:"uuid"`
+	Title    string     `json:"title" example:"Dashboard"`
+	Depth    int        `json:"depth" example:"0"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty" format:"uuid"`
+}
+
+// MenuTreeReport is the result of checking the structural invariants of the
+// whole menu tree
+type MenuTreeReport struct {
+	Valid      bool                `json:"valid" example:"false"`
+	Violations []MenuTreeViolation `json:"violations"`
+}
+
+// MenuTreeViolation is one broken invariant and the menus involved. Rule is
+// one of cycle, orphan, sibling_gap, duplicate_path or depth_exceeded.
+type MenuTreeViolation struct {
+	Rule    string      `json:"rule" example:"sibling_gap"`
+	Message string      `json:"message" example:"order_index values under the parent are not 0..n-1"`
+	MenuIDs []uuid.UUID `json:"menu_ids"`
+}
+
+// MenuExport is the document produced by the menu export endpoint and
+// accepted back by the import endpoint
+type MenuExport struct {
+	Menus []MenuTreeNode `json:"menus"`
+}
+
+// MenuTreeNode is a portable copy of a menu and its children, as exported,
+// imported and kept in preset snapshots; sibling order is given by position
+// in Children. Exports carry the ID, preset snapshots leave it out.
+type MenuTreeNode struct {
+	ID       *uuid.UUID     `json:"id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000" format:"uuid"`
+	Title    string         `json:"title" example:"Dashboard"`
+	Path     *string        `json:"path,omitempty" example:"/dashboard"`
+	Icon     *string        `json:"icon,omitempty" example:"icon-dashboard"`
+	IsActive *bool          `json:"is_active,omitempty" example:"true"`
+	Children []MenuTreeNode `json:"children,omitempty"`
+}
@@ -1,21 +1,43 @@
 package models
 
-// APIResponse is the standard API response wrapper
+// APIResponse is the standard API response wrapper. Code is the
+// machine-readable error code (see error_codes.go) of a failed request.
+// Errors maps request fields to their validation messages on 400 responses;
+// Error stays the joined message for clients that only read that.
 type APIResponse struct {
-	Status  int         `json:"status" example:"200"`
-	Message string      `json:"message" example:"Success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty" example:""`
+	Status  int               `json:"status" example:"200"`
+	Message string            `json:"message" example:"Success"`
+	Code    string            `json:"code,omitempty" example:""`
+	Data    interface{}       `json:"data,omitempty"`
+	Error   string            `json:"error,omitempty" example:""`
+	Errors  map[string]string `json:"errors,omitempty"`
 }
 
 // PaginatedResponse is the response wrapper for paginated data
 type PaginatedResponse struct {
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
-	Page    int         `json:"page"`
-	Limit   int         `json:"limit"`
-	Total   int64       `json:"total"`
+	Status     int              `json:"status"`
+	Message    string           `json:"message"`
+	Data       interface{}      `json:"data"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+	Total      int64            `json:"total"`
+	TotalPages int              `json:"total_pages"`
+	HasNext    bool             `json:"has_next"`
+	HasPrev    bool             `json:"has_prev"`
+	Links      *PaginationLinks `json:"links,omitempty"`
+}
+
+// PaginationLinks holds the navigation URLs of a paginated response
+type PaginationLinks struct {
+	First string `json:"first"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last"`
+}
+
+// RetryAfterData is the data payload of a 429 response
+type RetryAfterData struct {
+	RetryAfterSeconds int `json:"retry_after_seconds" example:"30"`
 }
 
 // LoginRequest is the request body for login
@@ -0,0 +1,113 @@
+package query_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/query"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var allowedSorts = []string{"order_index", "title", "created_at"}
+
+// parse runs ParseListParams against a request for the given query string
+func parse(t *testing.T, rawQuery string) (query.ListParams, error) {
+	t.Helper()
+
+	var (
+		params   query.ListParams
+		parseErr error
+	)
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		params, parseErr = query.ParseListParams(c, allowedSorts)
+		return nil
+	})
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/?"+rawQuery, nil)); err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+	return params, parseErr
+}
+
+func TestParseListParams_Defaults(t *testing.T) {
+	params, err := parse(t, "")
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEqual(t, 1, params.Page)
+	testutil.AssertEqual(t, query.DefaultLimit, params.Limit)
+	testutil.AssertEqual(t, "order_index", params.Sort)
+	testutil.AssertEqual(t, false, params.Desc)
+	testutil.AssertNil(t, params.From)
+	testutil.AssertNil(t, params.To)
+}
+
+func TestParseListParams_Valid(t *testing.T) {
+	params, err := parse(t, "page=3&limit=25&sort=title&order=DESC&search=%20dash%20&created_from=2026-01-01&created_to=2026-01-31")
+
+	testutil.AssertNil(t, err)
+	testutil.AssertEqual(t, 3, params.Page)
+	testutil.AssertEqual(t, 25, params.Limit)
+	testutil.AssertEqual(t, 50, params.Offset())
+	testutil.AssertEqual(t, "title", params.Sort)
+	testutil.AssertEqual(t, true, params.Desc)
+	testutil.AssertEqual(t, "dash", params.Search)
+	testutil.AssertEqual(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), *params.From)
+	testutil.AssertEqual(t, time.Date(2026, 1, 31, 23, 59, 59, 999999999, time.UTC), *params.To)
+}
+
+func TestParseListParams_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawQuery string
+		expected string
+	}{
+		{"zero page", "page=0", "page must be a positive integer"},
+		{"non-numeric limit", "limit=all", "limit must be between 1 and 100"},
+		{"limit over max", "limit=101", "limit must be between 1 and 100"},
+		{"sort not allowed", "sort=password", "sort must be one of: order_index, title, created_at"},
+		{"bad order", "order=sideways", "order must be either asc or desc"},
+		{"bad date", "created_from=yesterday", "created_from: must be a date"},
+		{"inverted range", "created_from=2026-02-01&created_to=2026-01-01", "created_from must not be after created_to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(t, tt.rawQuery)
+
+			testutil.AssertNotNil(t, err)
+			testutil.AssertContains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestListParams_Apply(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	for i, title := range []string{"Alpha", "Bravo", "Charlie", "Delta", "Echo"} {
+		testutil.CreateMenuFixture(db, title, nil, i)
+	}
+	old := testutil.CreateMenuFixture(db, "Ancient", nil, 5)
+	db.Model(old).UpdateColumn("created_at", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	from := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := query.ListParams{Page: 2, Limit: 2, Sort: "title", Desc: true, From: &from}
+
+	var menus []models.Menu
+	if err := params.Apply(db.Model(&models.Menu{})).Find(&menus).Error; err != nil {
+		t.Fatalf("Failed to apply params: %v", err)
+	}
+
+	testutil.AssertLen(t, menus, 2)
+	testutil.AssertEqual(t, "Charlie", menus[0].Title)
+	testutil.AssertEqual(t, "Bravo", menus[1].Title)
+
+	var total int64
+	params.Filter(db.Model(&models.Menu{})).Count(&total)
+	testutil.AssertEqual(t, int64(5), total, "Ancient menu is outside the created_at range")
+}
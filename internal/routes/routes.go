@@ -1,7 +1,15 @@
 package routes
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/config"
 	"github.com/andhikadk/stk-test-be/internal/handlers"
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/services"
+	pkgutils "github.com/andhikadk/stk-test-be/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
 	fiberSwagger "github.com/gofiber/swagger"
@@ -9,27 +17,144 @@ import (
 
 func SetupRoutes(app *fiber.App) {
 	app.Get("/health", handlers.HealthCheck)
+	app.Get("/ready", handlers.ReadinessCheck)
+	app.Get("/version", handlers.VersionInfo)
+	app.Get("/metrics", handlers.Metrics)
 
+	// Registered before the UI so the document is always the embedded one
+	app.Get("/swagger/doc.json", handlers.SwaggerJSON)
 	app.Get("/swagger/*", fiberSwagger.HandlerDefault)
 
-	apiGroup := app.Group("/api")
+	apiGroup := app.Group("/api", middleware.RateLimitMiddleware(rateLimit()))
 	{
+		// Menu routes are public: there is no AuthMiddleware in front of
+		// /api, and the handler tests call /api/menus without credentials.
+		// The per-IP rate limit on the group is their only abuse guard,
+		// except for the endpoints that wipe the whole tree or expose
+		// diagnostics, which need the static admin token.
+		// Static paths are registered before /:id so they are not captured
+		// as IDs.
+		adminOnly := middleware.AdminTokenMiddleware(adminToken())
 		menusGroup := apiGroup.Group("/menus")
 		{
+			menusGroup.Get("/presets", handlers.GetMenuPresets)
+			menusGroup.Post("/presets", handlers.CreateMenuPreset)
+			menusGroup.Post("/presets/:id/apply", adminOnly, handlers.ApplyMenuPreset)
+
+			menusGroup.Patch("/reorder-batch", handlers.ReorderSiblings)
+			menusGroup.Patch("/icons", handlers.AssignMenuIcons)
+
+			menusGroup.Get("/list", handlers.ListMenus)
+			menusGroup.Get("/orphans", adminOnly, handlers.GetMenuOrphans)
+			menusGroup.Get("/validate", adminOnly, handlers.ValidateMenuTree)
+			menusGroup.Get("/select-options", handlers.GetMenuSelectOptions)
+			menusGroup.Get("/export", handlers.ExportMenus)
+			menusGroup.Post("/import", replaceImportOnly(adminOnly), handlers.ImportMenus)
+			menusGroup.Post("/bulk", handlers.BulkCreateMenus)
+
 			menusGroup.Get("/", handlers.GetMenus)
 			menusGroup.Get("/:id", handlers.GetMenu)
-			menusGroup.Post("/", handlers.CreateMenu)
+			menusGroup.Post("/", middleware.IdempotencyMiddleware(idempotencyTTL()), handlers.CreateMenu)
 			menusGroup.Put("/:id", handlers.UpdateMenu)
 			menusGroup.Delete("/:id", handlers.DeleteMenu)
+			menusGroup.Post("/:id/restore", handlers.RestoreMenu)
+			menusGroup.Post("/:id/clone", handlers.CloneMenu)
+			menusGroup.Patch("/:id/toggle", handlers.ToggleMenu)
 			menusGroup.Patch("/:id/move", handlers.MoveMenu)
 			menusGroup.Patch("/:id/reorder", handlers.ReorderMenu)
 		}
 	}
 
 	app.Use(func(c *fiber.Ctx) error {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"status":  fiber.StatusNotFound,
-			"message": "endpoint not found",
-		})
+		if allowed := allowedMethods(app, c.Path()); len(allowed) > 0 {
+			c.Set(fiber.HeaderAllow, strings.Join(allowed, ", "))
+			return pkgutils.ErrorResponse(c, fiber.StatusMethodNotAllowed, fmt.Sprintf("%s is not supported for %s", c.Method(), c.Path()))
+		}
+
+		return pkgutils.ErrorResponse(c, fiber.StatusNotFound, "endpoint not found")
 	})
 }
+
+// rateLimit returns the configured /api rate limit, falling back to the
+// defaults when no config is loaded (e.g. in tests)
+func rateLimit() (int, time.Duration) {
+	if config.AppConfig != nil && config.AppConfig.RateLimitMax > 0 && config.AppConfig.RateLimitWindow > 0 {
+		return config.AppConfig.RateLimitMax, config.AppConfig.RateLimitWindow
+	}
+	return config.DefaultRateLimitMax, config.DefaultRateLimitWindow
+}
+
+// idempotencyTTL returns the configured idempotency TTL, falling back to the
+// default when no config is loaded (e.g. in tests)
+func idempotencyTTL() time.Duration {
+	if config.AppConfig != nil && config.AppConfig.IdempotencyTTL > 0 {
+		return config.AppConfig.IdempotencyTTL
+	}
+	return config.DefaultIdempotencyTTL
+}
+
+// adminToken returns the configured admin token; without a loaded config
+// (e.g. in tests) it is empty, which disables the admin endpoints
+func adminToken() string {
+	if config.AppConfig != nil {
+		return config.AppConfig.AdminToken
+	}
+	return ""
+}
+
+// replaceImportOnly applies guard to imports in replace mode only; merge
+// imports never delete menus
+func replaceImportOnly(guard fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if services.ImportMode(c.Query("mode")) == services.ImportModeReplace {
+			return guard(c)
+		}
+		return c.Next()
+	}
+}
+
+// allowedMethods returns the methods registered for path, in Fiber's
+// canonical method order; it is empty when no route matches the path at all
+func allowedMethods(app *fiber.App, path string) []string {
+	registered := make(map[string]bool)
+	for _, route := range app.GetRoutes(true) {
+		if matchRoute(route.Path, path) {
+			registered[route.Method] = true
+		}
+	}
+
+	allowed := make([]string, 0, len(registered))
+	for _, method := range fiber.DefaultMethods {
+		if registered[method] {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
+
+// matchRoute reports whether path matches a route pattern made of literal
+// segments, ":param" segments and a trailing "*" wildcard
+func matchRoute(pattern, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, segment := range patternSegments {
+		if segment == "*" {
+			return true
+		}
+		if i >= len(pathSegments) {
+			return false
+		}
+		if strings.HasPrefix(segment, ":") {
+			if pathSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+
+	return len(patternSegments) == len(pathSegments)
+}
@@ -0,0 +1,157 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/docs"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/routes"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+	"github.com/google/uuid"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMethodNotAllowed(t *testing.T) {
+	app := fiber.New()
+	routes.SetupRoutes(app)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		allow  string
+	}{
+		{
+			name:   "collection",
+			method: "DELETE",
+			path:   "/api/menus",
+			allow:  "GET, HEAD, POST",
+		},
+		{
+			name:   "path with param",
+			method: "GET",
+			path:   fmt.Sprintf("/api/menus/%s/move", uuid.New()),
+			allow:  "PATCH",
+		},
+		{
+			name:   "health",
+			method: "POST",
+			path:   "/health",
+			allow:  "GET, HEAD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(tt.method, tt.path, nil))
+
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			testutil.AssertStatusCode(t, fiber.StatusMethodNotAllowed, resp)
+			testutil.AssertEqual(t, tt.allow, resp.Header.Get(fiber.HeaderAllow))
+
+			var result models.APIResponse
+			testutil.ParseJSONResponse(t, resp.Body, &result)
+
+			testutil.AssertEqual(t, fiber.StatusMethodNotAllowed, result.Status)
+			testutil.AssertEqual(t, models.CodeMethodNotAllowed, result.Code)
+		})
+	}
+}
+
+func TestUnknownPathStillNotFound(t *testing.T) {
+	app := fiber.New()
+	routes.SetupRoutes(app)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/unknown", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusNotFound, resp)
+	testutil.AssertEmpty(t, resp.Header.Get(fiber.HeaderAllow))
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	testutil.AssertEqual(t, models.CodeNotFound, result.Code)
+}
+
+// swaggerSpec is the part of the generated OpenAPI document the tests check
+type swaggerSpec struct {
+	Paths map[string]map[string]struct {
+		Parameters []struct {
+			Name   string `json:"name"`
+			In     string `json:"in"`
+			Type   string `json:"type"`
+			Format string `json:"format"`
+		} `json:"parameters"`
+	} `json:"paths"`
+}
+
+func TestSwaggerSpec_DocumentsAPIRoutes(t *testing.T) {
+	var spec swaggerSpec
+	if err := json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &spec); err != nil {
+		t.Fatalf("Failed to parse swagger spec: %v", err)
+	}
+
+	for _, path := range []string{"/api/menus", "/api/menus/{id}", "/api/menus/{id}/move", "/api/menus/{id}/reorder"} {
+		if _, ok := spec.Paths[path]; !ok {
+			t.Errorf("Expected %s to be documented", path)
+		}
+	}
+
+	app := fiber.New()
+	routes.SetupRoutes(app)
+
+	param := regexp.MustCompile(`:(\w+)`)
+	for _, route := range app.GetRoutes(true) {
+		if !strings.HasPrefix(route.Path, "/api/") || route.Method == fiber.MethodHead {
+			continue
+		}
+
+		path := param.ReplaceAllString(strings.TrimSuffix(route.Path, "/"), "{$1}")
+		operation, ok := spec.Paths[path][strings.ToLower(route.Method)]
+		if !ok {
+			t.Errorf("Expected %s %s to be documented", route.Method, path)
+			continue
+		}
+
+		for _, p := range operation.Parameters {
+			if p.In == "path" && p.Name == "id" && (p.Type != "string" || p.Format != "uuid") {
+				t.Errorf("Expected the id of %s %s to be a uuid string, got %s/%s", route.Method, path, p.Type, p.Format)
+			}
+		}
+	}
+}
+
+func TestSwaggerDocJSON(t *testing.T) {
+	app := fiber.New()
+	routes.SetupRoutes(app)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/swagger/doc.json", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+	testutil.AssertEqual(t, fiber.MIMEApplicationJSONCharsetUTF8, resp.Header.Get(fiber.HeaderContentType))
+
+	var doc struct {
+		Info struct {
+			Title string `json:"title"`
+		} `json:"info"`
+	}
+	testutil.ParseJSONResponse(t, resp.Body, &doc)
+
+	testutil.AssertEqual(t, "STK Test API - Menu Management", doc.Info.Title)
+}
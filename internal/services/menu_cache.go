@@ -0,0 +1,105 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/config"
+	"github.com/andhikadk/stk-test-be/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// menuTreeCache holds built menu trees keyed by the options they were built
+// with. It is shared by every MenuService, since handlers create one per
+// request.
+type menuTreeCache struct {
+	mu      sync.RWMutex
+	entries map[TreeOptions]menuTreeEntry
+	// generation is bumped by every invalidation, so a tree read from the
+	// database before a write committed is not stored after it
+	generation uint64
+}
+
+type menuTreeEntry struct {
+	tree      []models.Menu
+	expiresAt time.Time
+}
+
+var treeCache = &menuTreeCache{entries: make(map[TreeOptions]menuTreeEntry)}
+
+// get returns a copy of the cached tree for opts, if there is a fresh one
+func (c *menuTreeCache) get(opts TreeOptions) ([]models.Menu, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[opts]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return cloneMenuTree(entry.tree), true
+}
+
+// currentGeneration returns the generation to pass to set for a tree about
+// to be read from the database
+func (c *menuTreeCache) currentGeneration() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation
+}
+
+// set stores a copy of tree unless the cache was invalidated since
+// generation was read
+func (c *menuTreeCache) set(opts TreeOptions, tree []models.Menu, generation uint64, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.entries[opts] = menuTreeEntry{tree: cloneMenuTree(tree), expiresAt: time.Now().Add(ttl)}
+}
+
+func (c *menuTreeCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	clear(c.entries)
+}
+
+// InvalidateMenuCache drops every cached menu tree. The services call it
+// after each write; call it after changing menus in the database directly.
+func InvalidateMenuCache() {
+	treeCache.invalidate()
+}
+
+// menuCacheTTL returns how long a built tree may be served from the cache;
+// 0 disables caching, as does running without a loaded config (e.g. in tests)
+func menuCacheTTL() time.Duration {
+	if config.AppConfig == nil || !config.AppConfig.MenuCacheEnabled {
+		return 0
+	}
+	return config.AppConfig.MenuCacheTTL
+}
+
+// inTransaction reports whether db is bound to an open transaction, whose
+// uncommitted reads must not be cached
+func inTransaction(db *gorm.DB) bool {
+	_, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok
+}
+
+// cloneMenuTree copies menus and their children so cached trees cannot be
+// changed through the slices handed to callers
+func cloneMenuTree(menus []models.Menu) []models.Menu {
+	if menus == nil {
+		return nil
+	}
+	cloned := make([]models.Menu, len(menus))
+	for i, menu := range menus {
+		menu.Children = cloneMenuTree(menu.Children)
+		cloned[i] = menu
+	}
+	return cloned
+}
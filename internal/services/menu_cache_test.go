@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/config"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+)
+
+func withMenuCache(t *testing.T, ttl time.Duration) {
+	t.Helper()
+	original := config.AppConfig
+	config.AppConfig = &config.Config{MenuCacheEnabled: true, MenuCacheTTL: ttl}
+	InvalidateMenuCache()
+	t.Cleanup(func() {
+		config.AppConfig = original
+		InvalidateMenuCache()
+	})
+}
+
+func treeTitles(t *testing.T, s *MenuService) []string {
+	t.Helper()
+	tree, err := s.GetMenuTree(TreeOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get menu tree: %v", err)
+	}
+	titles := make([]string, len(tree))
+	for i, menu := range tree {
+		titles[i] = menu.Title
+	}
+	return titles
+}
+
+func TestGetMenuTree_SecondReadServedFromCache(t *testing.T) {
+	withMenuCache(t, time.Minute)
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	s := NewMenuService(db)
+	testutil.CreateMenuFixture(db, "Dashboard", nil, 0)
+	testutil.AssertEqual(t, []string{"Dashboard"}, treeTitles(t, s))
+
+	// Written behind the service's back, so only a cache miss would see it
+	testutil.CreateMenuFixture(db, "Settings", nil, 1)
+	testutil.AssertEqual(t, []string{"Dashboard"}, treeTitles(t, s))
+}
+
+func TestGetMenuTree_MutationInvalidatesCache(t *testing.T) {
+	withMenuCache(t, time.Minute)
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	s := NewMenuService(db)
+	dashboard := testutil.CreateMenuFixture(db, "Dashboard", nil, 0)
+	testutil.AssertEqual(t, []string{"Dashboard"}, treeTitles(t, s))
+
+	if err := s.CreateMenu(&models.Menu{Title: "Settings", OrderIndex: 1}); err != nil {
+		t.Fatalf("Failed to create menu: %v", err)
+	}
+	testutil.AssertEqual(t, []string{"Dashboard", "Settings"}, treeTitles(t, s))
+
+	if err := s.DeleteMenu(dashboard.ID); err != nil {
+		t.Fatalf("Failed to delete menu: %v", err)
+	}
+	testutil.AssertEqual(t, []string{"Settings"}, treeTitles(t, s))
+}
+
+func TestGetMenuTree_CacheExpires(t *testing.T) {
+	withMenuCache(t, time.Millisecond)
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	s := NewMenuService(db)
+	testutil.CreateMenuFixture(db, "Dashboard", nil, 0)
+	testutil.AssertEqual(t, []string{"Dashboard"}, treeTitles(t, s))
+
+	testutil.CreateMenuFixture(db, "Settings", nil, 1)
+	time.Sleep(5 * time.Millisecond)
+	testutil.AssertEqual(t, []string{"Dashboard", "Settings"}, treeTitles(t, s))
+}
+
+func TestGetMenuTree_CachedTreeIsNotShared(t *testing.T) {
+	withMenuCache(t, time.Minute)
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	s := NewMenuService(db)
+	testutil.CreateMenuFixture(db, "Dashboard", nil, 0)
+
+	tree, err := s.GetMenuTree(TreeOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get menu tree: %v", err)
+	}
+	tree[0].Title = "Changed"
+
+	testutil.AssertEqual(t, []string{"Dashboard"}, treeTitles(t, s))
+}
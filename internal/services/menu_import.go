@@ -0,0 +1,261 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+type ImportMode string
+
+const (
+	// ImportModeReplace removes every existing menu before importing
+	ImportModeReplace ImportMode = "replace"
+	// ImportModeMerge updates menus whose ID is already stored and creates the rest
+	ImportModeMerge ImportMode = "merge"
+)
+
+var ErrInvalidImportMode = errors.New("mode must be either replace or merge")
+
+// ExportTree returns the live menu tree in the import/export document shape
+func (s *MenuService) ExportTree() (*models.MenuExport, error) {
+	tree, err := s.GetMenuTree(TreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &models.MenuExport{Menus: toTreeNodes(tree, true)}, nil
+}
+
+// ImportTree writes the nodes in a single transaction. Nodes without an ID
+// get a fresh UUID; in merge mode a node updates the live menu with the same
+// path in place, or failing that the menu with its ID (even soft-deleted).
+// Sibling order follows array position and a missing is_active means active.
+// Merged menus keep their other children, and the resulting subtree must fit
+// the maximum depth. In merge mode imported menus are
+// placed after the existing menus of the groups they join, and the order
+// indexes of every group they join or leave are renumbered to 0..n-1.
+// A path already used by another menu fails the import with ErrMenuPathTaken.
+// It returns how many menus were created and updated.
+func (s *MenuService) ImportTree(nodes []models.MenuTreeNode, mode ImportMode) (int, int, error) {
+	defer InvalidateMenuCache()
+
+	if mode != ImportModeReplace && mode != ImportModeMerge {
+		return 0, 0, ErrInvalidImportMode
+	}
+
+	if treeHeight(nodes) > s.maxDepth() {
+		return 0, 0, ErrMenuDepthExceeded
+	}
+
+	var created, updated int
+	err := s.lockedTransaction(func(tx *gorm.DB) error {
+		if mode == ImportModeReplace {
+			// Hard delete so that IDs carried by the document can be reused
+			if err := tx.Unscoped().Where("1 = 1").Delete(&models.Menu{}).Error; err != nil {
+				return err
+			}
+		}
+
+		writer := newTreeWriter(s.withTx(tx), mode == ImportModeMerge)
+		if err := writer.write(nil, nodes); err != nil {
+			return err
+		}
+		created, updated = writer.created, writer.updated
+		return writer.renumber()
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return created, updated, nil
+}
+
+// treeWriter writes a tree document into the menus table; it backs both the
+// import endpoint and preset restore
+type treeWriter struct {
+	s *MenuService
+	// merge updates nodes matching a stored path or ID instead of creating them
+	merge   bool
+	created int
+	updated int
+	// groups holds every sibling group written to or moved out of
+	groups map[string]*uuid.UUID
+	// written holds the IDs of the menus created or updated so far
+	written map[uuid.UUID]bool
+}
+
+// newTreeWriter returns a writer for s, which must be bound to a transaction
+func newTreeWriter(s *MenuService, merge bool) *treeWriter {
+	return &treeWriter{s: s, merge: merge, groups: make(map[string]*uuid.UUID), written: make(map[uuid.UUID]bool)}
+}
+
+// write stores nodes under parentID after the group's other live menus,
+// then their children
+func (w *treeWriter) write(parentID *uuid.UUID, nodes []models.MenuTreeNode) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	tx := w.s.db
+	w.groups[groupKey(parentID)] = parentID
+
+	matches := make([]*models.Menu, len(nodes))
+	var ids []uuid.UUID
+	for i, node := range nodes {
+		if w.merge {
+			current, err := w.match(node)
+			if err != nil {
+				return err
+			}
+			if current != nil {
+				matches[i] = current
+				ids = append(ids, current.ID)
+				continue
+			}
+		}
+		if node.ID != nil {
+			ids = append(ids, *node.ID)
+		}
+	}
+	others := tx.Model(&models.Menu{}).Scopes(siblingsOf(parentID))
+	if len(ids) > 0 {
+		others = others.Where("id NOT IN ?", ids)
+	}
+	var base int
+	if err := others.Select("COALESCE(MAX(order_index) + 1, 0)").Scan(&base).Error; err != nil {
+		return err
+	}
+
+	for index, node := range nodes {
+		menu := models.Menu{
+			ParentID:   parentID,
+			Title:      node.Title,
+			Path:       node.Path,
+			Icon:       node.Icon,
+			OrderIndex: base + index,
+			// Snapshots taken before menus could be deactivated carry no flag
+			IsActive: node.IsActive == nil || *node.IsActive,
+		}
+		if node.ID != nil {
+			menu.ID = *node.ID
+		}
+
+		if current := matches[index]; current != nil {
+			// Two nodes with the same path would both match one menu
+			if w.written[current.ID] {
+				return ErrMenuPathTaken
+			}
+			menu.ID = current.ID
+			if err := w.s.checkPathAvailable(menu.Path, &menu.ID); err != nil {
+				return err
+			}
+			if !current.DeletedAt.Valid {
+				w.groups[groupKey(current.ParentID)] = current.ParentID
+			}
+			if err := tx.Unscoped().Model(&models.Menu{}).Where("id = ?", menu.ID).Updates(map[string]interface{}{
+				"parent_id":   menu.ParentID,
+				"title":       menu.Title,
+				"path":        menu.Path,
+				"icon":        menu.Icon,
+				"order_index": menu.OrderIndex,
+				"is_active":   menu.IsActive,
+				"deleted_at":  nil,
+			}).Error; err != nil {
+				return err
+			}
+			w.updated++
+		} else {
+			if err := w.s.checkPathAvailable(menu.Path, nil); err != nil {
+				return err
+			}
+			if err := createMenuRow(tx, &menu); err != nil {
+				return err
+			}
+			w.created++
+		}
+		w.written[menu.ID] = true
+
+		if err := w.write(&menu.ID, node.Children); err != nil {
+			return err
+		}
+
+		// A merged menu keeps the children the document does not mention,
+		// which the document's own height check cannot see
+		if matches[index] != nil {
+			height, err := w.s.getSubtreeHeight(menu.ID)
+			if err != nil {
+				return err
+			}
+			if err := w.s.checkDepth(parentID, height); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// match returns the stored menu a merged node updates: the live menu with
+// the node's path, or else the menu with the node's ID, even soft-deleted.
+// It returns nil when the node is new.
+func (w *treeWriter) match(node models.MenuTreeNode) (*models.Menu, error) {
+	tx := w.s.db
+	var stored []models.Menu
+	if node.Path != nil {
+		if err := tx.Where("path = ?", *node.Path).Limit(1).Find(&stored).Error; err != nil {
+			return nil, err
+		}
+	}
+	if len(stored) == 0 && node.ID != nil {
+		if err := tx.Unscoped().Where("id = ?", *node.ID).Limit(1).Find(&stored).Error; err != nil {
+			return nil, err
+		}
+	}
+	if len(stored) == 0 {
+		return nil, nil
+	}
+	return &stored[0], nil
+}
+
+// renumber rewrites the order indexes of the touched groups to 0..n-1,
+// keeping their current order
+func (w *treeWriter) renumber() error {
+	for _, parentID := range w.groups {
+		if err := renumberGroup(w.s.db, parentID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toTreeNodes copies a loaded menu tree into document nodes, with or
+// without the menu IDs
+func toTreeNodes(menus []models.Menu, withIDs bool) []models.MenuTreeNode {
+	nodes := make([]models.MenuTreeNode, 0, len(menus))
+	for _, menu := range menus {
+		id := menu.ID
+		isActive := menu.IsActive
+		node := models.MenuTreeNode{
+			Title:    menu.Title,
+			Path:     menu.Path,
+			Icon:     menu.Icon,
+			IsActive: &isActive,
+			Children: toTreeNodes(menu.Children, withIDs),
+		}
+		if withIDs {
+			node.ID = &id
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// treeHeight returns the number of levels in the document
+func treeHeight(nodes []models.MenuTreeNode) int {
+	height := 0
+	for _, node := range nodes {
+		height = max(height, 1+treeHeight(node.Children))
+	}
+	return height
+}
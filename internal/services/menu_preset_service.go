@@ -0,0 +1,84 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPresetNotFound  = errors.New("menu preset not found")
+	ErrPresetNameTaken = errors.New("menu preset name already in use")
+)
+
+type MenuPresetService struct {
+	db *gorm.DB
+}
+
+func NewMenuPresetService(db *gorm.DB) *MenuPresetService {
+	return &MenuPresetService{db: db}
+}
+
+func (s *MenuPresetService) GetAllPresets() ([]models.MenuPreset, error) {
+	var presets []models.MenuPreset
+	if err := s.db.Order("created_at ASC").Find(&presets).Error; err != nil {
+		return nil, err
+	}
+	return presets, nil
+}
+
+// SavePreset snapshots the current menu tree under the given name
+func (s *MenuPresetService) SavePreset(name string) (*models.MenuPreset, error) {
+	var count int64
+	if err := s.db.Model(&models.MenuPreset{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrPresetNameTaken
+	}
+
+	tree, err := NewMenuService(s.db).GetMenuTree(TreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := json.Marshal(toTreeNodes(tree, false))
+	if err != nil {
+		return nil, err
+	}
+
+	preset := &models.MenuPreset{
+		Name:     name,
+		Snapshot: string(snapshot),
+	}
+	if err := s.db.Create(preset).Error; err != nil {
+		return nil, err
+	}
+
+	return preset, nil
+}
+
+// ApplyPreset replaces the live menu tree with the preset's snapshot,
+// generating fresh IDs for every menu. It goes through ImportTree's replace
+// mode, so the old tree is hard-deleted exactly as an import would.
+func (s *MenuPresetService) ApplyPreset(id uuid.UUID) error {
+	var preset models.MenuPreset
+	if err := s.db.Where("id = ?", id).First(&preset).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPresetNotFound
+		}
+		return err
+	}
+
+	var nodes []models.MenuTreeNode
+	if err := json.Unmarshal([]byte(preset.Snapshot), &nodes); err != nil {
+		return err
+	}
+
+	_, _, err := NewMenuService(s.db).ImportTree(nodes, ImportModeReplace)
+	return err
+}
@@ -2,21 +2,81 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 
+	"github.com/andhikadk/stk-test-be/config"
+	"github.com/andhikadk/stk-test-be/internal/database"
 	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/query"
 	"github.com/google/uuid"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const defaultMenuMaxDepth = 5
+
+// MenuListSorts are the columns the flat menu listing can be sorted by
+var MenuListSorts = []string{"order_index", "title", "created_at", "updated_at"}
+
+var (
+	ErrMenuNotFound        = errors.New("menu not found")
+	ErrParentMenuNotFound  = errors.New("parent menu not found")
+	ErrMenuDepthExceeded   = errors.New("menu depth limit exceeded")
+	ErrMenuCycle           = errors.New("menu cannot be moved under itself or one of its descendants")
+	ErrSiblingSetMismatch  = errors.New("ordered_ids must match the current children of the parent exactly")
+	ErrDeletedMenuNotFound = errors.New("deleted menu not found")
+	ErrParentMenuDeleted   = errors.New("parent menu is deleted; restore it first")
+	ErrMenuPathTaken       = errors.New("menu path already in use")
+	ErrOldIndexOutOfRange  = errors.New("old_index is outside the menu's sibling group")
+	ErrStaleOldIndex       = errors.New("old_index does not match the menu's current position")
+)
+
+// TreeOptions controls which menus GetMenuTree includes
+type TreeOptions struct {
+	IncludeDeleted bool
+	// ActiveOnly prunes inactive menus and everything below them
+	ActiveOnly bool
+}
+
 type MenuService struct {
 	db *gorm.DB
+	// actorID is the authenticated user recorded in created_by/updated_by
+	actorID *uint
 }
 
 func NewMenuService(db *gorm.DB) *MenuService {
 	return &MenuService{db: db}
 }
 
+// WithActor returns a copy of the service that records userID as the creator
+// or last editor of the menus it writes; a nil userID leaves them untouched
+func (s *MenuService) WithActor(userID *uint) *MenuService {
+	clone := *s
+	clone.actorID = userID
+	return &clone
+}
+
+// withTx returns a copy of the service whose queries run in tx
+func (s *MenuService) withTx(tx *gorm.DB) *MenuService {
+	clone := *s
+	clone.db = tx
+	return &clone
+}
+
+// withUpdatedBy adds the actor to a column map when one is set
+func (s *MenuService) withUpdatedBy(values map[string]interface{}) map[string]interface{} {
+	if s.actorID != nil {
+		values["updated_by"] = *s.actorID
+	}
+	return values
+}
+
 func (s *MenuService) GetAllMenus() ([]models.Menu, error) {
 	var menus []models.Menu
 	if err := s.db.Where("parent_id IS NULL").Preload("Children").Find(&menus).Error; err != nil {
@@ -25,174 +85,940 @@ func (s *MenuService) GetAllMenus() ([]models.Menu, error) {
 	return menus, nil
 }
 
+// GetMenuByID returns the menu with its whole subtree attached
 func (s *MenuService) GetMenuByID(id uuid.UUID) (*models.Menu, error) {
+	return s.GetMenuSubtree(id, 0)
+}
+
+// GetMenuSubtree returns the menu with the first depth levels below it
+// attached as Children, each level in order_index order; a depth of 0
+// attaches the whole subtree
+func (s *MenuService) GetMenuSubtree(id uuid.UUID, depth int) (*models.Menu, error) {
 	var menu models.Menu
-	if err := s.db.Preload("Children").Where("id = ?", id).First(&menu).Error; err != nil {
+	if err := s.db.Where("id = ?", id).First(&menu).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("menu not found")
+			return nil, ErrMenuNotFound
 		}
 		return nil, err
 	}
+
+	childrenOf := make(map[uuid.UUID][]models.Menu)
+	visited := map[uuid.UUID]bool{id: true}
+	level := []uuid.UUID{id}
+
+	for d := 0; len(level) > 0 && (depth == 0 || d < depth); d++ {
+		var children []models.Menu
+		if err := s.db.Where("parent_id IN ?", level).Order("order_index ASC").Find(&children).Error; err != nil {
+			return nil, err
+		}
+
+		next := make([]uuid.UUID, 0, len(children))
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			childrenOf[*child.ParentID] = append(childrenOf[*child.ParentID], child)
+			next = append(next, child.ID)
+		}
+		level = next
+	}
+
+	menu.Children = attachChildren(menu.ID, childrenOf)
 	return &menu, nil
 }
 
+// attachChildren returns the children of parentID from childrenOf, each with
+// its own children attached
+func attachChildren(parentID uuid.UUID, childrenOf map[uuid.UUID][]models.Menu) []models.Menu {
+	var children []models.Menu
+	for _, child := range childrenOf[parentID] {
+		child.Children = attachChildren(child.ID, childrenOf)
+		children = append(children, child)
+	}
+	return children
+}
+
+// CountDescendants returns the number of direct children and the size of the
+// whole subtree below the menu, excluding the menu itself
+func (s *MenuService) CountDescendants(id uuid.UUID) (int64, int64, error) {
+	var childCount, descendantCount int64
+	visited := map[uuid.UUID]bool{id: true}
+	level := []uuid.UUID{id}
+
+	for depth := 0; len(level) > 0; depth++ {
+		var childIDs []uuid.UUID
+		if err := s.db.Model(&models.Menu{}).Where("parent_id IN ?", level).Pluck("id", &childIDs).Error; err != nil {
+			return 0, 0, err
+		}
+
+		next := make([]uuid.UUID, 0, len(childIDs))
+		for _, childID := range childIDs {
+			if visited[childID] {
+				continue
+			}
+			visited[childID] = true
+			next = append(next, childID)
+		}
+
+		if depth == 0 {
+			childCount = int64(len(next))
+		}
+		descendantCount += int64(len(next))
+		level = next
+	}
+
+	return childCount, descendantCount, nil
+}
+
 func (s *MenuService) CreateMenu(menu *models.Menu) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		siblingCount, err := s.getSiblingCount(menu.ParentID)
-		if err != nil {
+	defer InvalidateMenuCache()
+
+	return s.lockedTransaction(func(tx *gorm.DB) error {
+		return s.withTx(tx).insertMenu(menu)
+	})
+}
+
+// AppendOrderIndex as a created menu's OrderIndex places it after its
+// current siblings
+const AppendOrderIndex = math.MaxInt32
+
+// BatchItemError reports which menu of a batch failed
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("menu %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// CreateMenus creates the menus in array order in a single transaction, so
+// a menu may be placed under one created earlier in the batch. Either every
+// menu is created or, on the first failure, none is and a *BatchItemError
+// names the offending menu.
+func (s *MenuService) CreateMenus(menus []*models.Menu) error {
+	defer InvalidateMenuCache()
+
+	return s.lockedTransaction(func(tx *gorm.DB) error {
+		txService := s.withTx(tx)
+		for i, menu := range menus {
+			if err := txService.insertMenu(menu); err != nil {
+				return &BatchItemError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// insertMenu checks the menu's depth and path and inserts it into its
+// sibling group at menu.OrderIndex, clamped to the end of the group. It must
+// run in a transaction.
+func (s *MenuService) insertMenu(menu *models.Menu) error {
+	if err := s.checkDepth(menu.ParentID, 1); err != nil {
+		return err
+	}
+
+	if err := s.checkPathAvailable(menu.Path, nil); err != nil {
+		return err
+	}
+
+	menu.CreatedBy = s.actorID
+	menu.UpdatedBy = s.actorID
+
+	// Two creates under the same parent would otherwise both count the
+	// siblings and claim the same order_index
+	siblings, err := lockSiblingGroup(s.db, menu.ParentID)
+	if err != nil {
+		return err
+	}
+
+	if menu.OrderIndex >= len(siblings) {
+		menu.OrderIndex = len(siblings)
+	} else {
+		if err := s.db.Model(&models.Menu{}).
+			Scopes(siblingsOf(menu.ParentID)).
+			Where("order_index >= ?", menu.OrderIndex).
+			Update("order_index", gorm.Expr("order_index + 1")).Error; err != nil {
 			return err
 		}
+	}
 
-		if menu.OrderIndex >= int(siblingCount) {
-			menu.OrderIndex = int(siblingCount)
-		} else {
-			baseQuery := tx.Model(&models.Menu{})
-			if menu.ParentID == nil {
-				baseQuery = baseQuery.Where("parent_id IS NULL")
-			} else {
-				baseQuery = baseQuery.Where("parent_id = ?", *menu.ParentID)
+	return createMenuRow(s.db, menu)
+}
+
+// UpsertMenu creates menu unless a menu with the same path already exists,
+// in which case that menu is updated with the given columns of menu, as in
+// UpdateMenu. menu.ID is set to the created or updated menu either way, and
+// created reports which one happened.
+func (s *MenuService) UpsertMenu(menu *models.Menu, columns []string) (created bool, err error) {
+	if menu.Path == nil {
+		return true, s.CreateMenu(menu)
+	}
+
+	var existing models.Menu
+	err = s.db.Where("path = ?", *menu.Path).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, s.CreateMenu(menu)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.UpdateMenu(existing.ID, menu, columns); err != nil {
+		return false, err
+	}
+	menu.ID = existing.ID
+	return false, nil
+}
+
+// createMenuRow inserts the menu as-is. GORM substitutes the column default
+// for zero values on create, so an inactive menu is written back afterwards.
+func createMenuRow(tx *gorm.DB, menu *models.Menu) error {
+	isActive := menu.IsActive
+	if err := tx.Create(menu).Error; err != nil {
+		return err
+	}
+	if !isActive {
+		menu.IsActive = false
+		return tx.Model(&models.Menu{}).Where("id = ?", menu.ID).Update("is_active", false).Error
+	}
+	return nil
+}
+
+// UpdateMenu writes only the given columns of menu, so omitted fields keep
+// their current value and explicitly provided nulls clear them. An
+// "order_index" column moves the menu to menu.OrderIndex within its sibling
+// group, shifting the siblings in between. A changed "parent_id" closes the
+// gap in the old group and appends the menu to the new one, or inserts it at
+// menu.OrderIndex when "order_index" is given too.
+func (s *MenuService) UpdateMenu(id uuid.UUID, menu *models.Menu, columns []string) error {
+	defer InvalidateMenuCache()
+
+	return s.lockedTransaction(func(tx *gorm.DB) error {
+		txService := s.withTx(tx)
+
+		var currentMenu models.Menu
+		if err := tx.Where("id = ?", id).First(&currentMenu).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMenuNotFound
 			}
+			return err
+		}
+
+		reorder := slices.Contains(columns, "order_index")
+		columns = slices.DeleteFunc(slices.Clone(columns), func(column string) bool {
+			return column == "order_index"
+		})
 
-			if err := baseQuery.
-				Where("order_index >= ?", menu.OrderIndex).
-				Update("order_index", gorm.Expr("order_index + 1")).Error; err != nil {
+		if slices.Contains(columns, "parent_id") && !sameParent(menu.ParentID, currentMenu.ParentID) {
+			if err := txService.checkMove(id, menu.ParentID); err != nil {
+				return err
+			}
+			var index *int
+			if reorder {
+				index = &menu.OrderIndex
+			}
+			newIndex, err := txService.moveToGroup(&currentMenu, menu.ParentID, index)
+			if err != nil {
 				return err
 			}
+			menu.OrderIndex = newIndex
+			columns = append(columns, "order_index")
+		} else if reorder {
+			if err := txService.reorderInGroup(id, menu.OrderIndex, nil); err != nil {
+				return err
+			}
+		}
+
+		if len(columns) == 0 {
+			return nil
+		}
+
+		if s.actorID != nil {
+			menu.UpdatedBy = s.actorID
+			columns = append(columns, "updated_by")
 		}
 
-		return tx.Create(menu).Error
+		if slices.Contains(columns, "path") {
+			if err := txService.checkPathAvailable(menu.Path, &id); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Menu{}).
+			Where("id = ?", id).
+			Select(append(columns, "updated_at")).
+			Updates(menu).Error
 	})
 }
 
-func (s *MenuService) UpdateMenu(id uuid.UUID, menu *models.Menu) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		var currentMenu models.Menu
-		if err := tx.Where("id = ?", id).First(&currentMenu).Error; err != nil {
+// sameParent reports whether two parent IDs name the same sibling group
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// moveToGroup takes the menu out of its sibling group, closing the gap it
+// leaves, and makes room for it in the group under newParentID at index,
+// clamped to the end of the group, or at the end when index is nil. It
+// returns the menu's new order_index; the caller writes it together with
+// parent_id. It must run in a transaction.
+func (s *MenuService) moveToGroup(menu *models.Menu, newParentID *uuid.UUID, index *int) (int, error) {
+	// Lock both groups in a fixed order so that two moves in opposite
+	// directions cannot deadlock
+	first, second := menu.ParentID, newParentID
+	if groupKey(second) < groupKey(first) {
+		first, second = second, first
+	}
+	var siblings []models.Menu
+	for _, parentID := range []*uuid.UUID{first, second} {
+		locked, err := lockSiblingGroup(s.db, parentID)
+		if err != nil {
+			return 0, err
+		}
+		if sameParent(parentID, newParentID) {
+			siblings = locked
+		}
+	}
+
+	var current models.Menu
+	if err := s.db.Select("order_index").Where("id = ?", menu.ID).First(&current).Error; err != nil {
+		return 0, err
+	}
+
+	if err := s.db.Model(&models.Menu{}).
+		Where("id != ?", menu.ID).
+		Scopes(siblingsOf(menu.ParentID)).
+		Where("order_index > ?", current.OrderIndex).
+		Update("order_index", gorm.Expr("order_index - 1")).Error; err != nil {
+		return 0, err
+	}
+
+	newIndex := len(siblings)
+	if index != nil && *index < newIndex {
+		newIndex = *index
+		if err := s.db.Model(&models.Menu{}).
+			Scopes(siblingsOf(newParentID)).
+			Where("order_index >= ?", newIndex).
+			Update("order_index", gorm.Expr("order_index + 1")).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	return newIndex, nil
+}
+
+// groupKey orders sibling groups for locking, the root group first
+func groupKey(parentID *uuid.UUID) string {
+	if parentID == nil {
+		return ""
+	}
+	return parentID.String()
+}
+
+// DeleteMenu soft-deletes the menu and its whole subtree with a single
+// timestamp and closes the gap it leaves among its siblings
+func (s *MenuService) DeleteMenu(id uuid.UUID) error {
+	defer InvalidateMenuCache()
+
+	return s.lockedTransaction(func(tx *gorm.DB) error {
+		var menus []models.Menu
+		if err := tx.Select("id", "parent_id", "order_index").Where("id = ?", id).Limit(1).Find(&menus).Error; err != nil {
+			return err
+		}
+		if len(menus) == 0 {
+			return nil
+		}
+		menu := menus[0]
+
+		if _, err := lockSiblingGroup(tx, menu.ParentID); err != nil {
+			return err
+		}
+
+		ids, err := collectSubtreeIDs(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", ids).Delete(&models.Menu{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Menu{}).
+			Scopes(siblingsOf(menu.ParentID)).
+			Where("order_index > ?", menu.OrderIndex).
+			Update("order_index", gorm.Expr("order_index - 1")).Error
+	})
+}
+
+// RestoreMenu clears the deletion timestamp of a soft-deleted menu and its
+// soft-deleted descendants. The menu is appended after its live siblings,
+// whose indexes may have been reused since it was deleted, and the groups
+// inside the restored subtree are renumbered to 0..n-1.
+func (s *MenuService) RestoreMenu(id uuid.UUID) error {
+	defer InvalidateMenuCache()
+
+	return s.lockedTransaction(func(tx *gorm.DB) error {
+		var menu models.Menu
+		if err := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&menu).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("menu not found")
+				return ErrDeletedMenuNotFound
+			}
+			return err
+		}
+
+		if menu.ParentID != nil {
+			var count int64
+			if err := tx.Model(&models.Menu{}).Where("id = ?", *menu.ParentID).Count(&count).Error; err != nil {
+				return err
 			}
+			if count == 0 {
+				return ErrParentMenuDeleted
+			}
+		}
+
+		siblings, err := lockSiblingGroup(tx, menu.ParentID)
+		if err != nil {
 			return err
 		}
 
-		if menu.OrderIndex != 0 && menu.OrderIndex != currentMenu.OrderIndex {
-			if err := s.ReorderMenu(id, menu.OrderIndex, &currentMenu.OrderIndex); err != nil {
+		ids, err := collectSubtreeIDs(tx.Unscoped(), id)
+		if err != nil {
+			return err
+		}
+
+		var paths []string
+		if err := tx.Unscoped().Model(&models.Menu{}).
+			Where("id IN ? AND path IS NOT NULL", ids).
+			Pluck("path", &paths).Error; err != nil {
+			return err
+		}
+		if len(paths) > 0 {
+			var count int64
+			if err := tx.Model(&models.Menu{}).Where("path IN ?", paths).Count(&count).Error; err != nil {
 				return err
 			}
+			if count > 0 {
+				return ErrMenuPathTaken
+			}
 		}
 
-		updates := map[string]interface{}{
-			"title":     menu.Title,
-			"parent_id": menu.ParentID,
-			"path":      menu.Path,
-			"icon":      menu.Icon,
+		if err := tx.Unscoped().Model(&models.Menu{}).
+			Where("id IN ?", ids).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Menu{}).
+			Where("id = ?", id).
+			Update("order_index", len(siblings)).Error; err != nil {
+			return err
 		}
 
-		return tx.Model(&models.Menu{}).Where("id = ?", id).Updates(updates).Error
+		for _, parentID := range ids {
+			if err := renumberGroup(tx, &parentID); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 }
 
-func (s *MenuService) DeleteMenu(id uuid.UUID) error {
-	if err := s.db.Where("parent_id = ?", id).Delete(&models.Menu{}).Error; err != nil {
+// renumberGroup rewrites the order indexes of the live menus under parentID
+// to 0..n-1, keeping their current order
+func renumberGroup(tx *gorm.DB, parentID *uuid.UUID) error {
+	var siblings []models.Menu
+	if err := tx.Select("id", "order_index").
+		Scopes(siblingsOf(parentID)).
+		Order("order_index ASC, id ASC").
+		Find(&siblings).Error; err != nil {
+		return err
+	}
+	for index, sibling := range siblings {
+		if sibling.OrderIndex == index {
+			continue
+		}
+		if err := tx.Model(&models.Menu{}).
+			Where("id = ?", sibling.ID).
+			Update("order_index", index).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPathAvailable returns ErrMenuPathTaken when another live menu already
+// uses path. A nil path never conflicts; excludeID skips the menu being updated.
+func (s *MenuService) checkPathAvailable(path *string, excludeID *uuid.UUID) error {
+	if path == nil {
+		return nil
+	}
+
+	query := s.db.Model(&models.Menu{}).Where("path = ?", *path)
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
 		return err
 	}
-	return s.db.Where("id = ?", id).Delete(&models.Menu{}).Error
+	if count > 0 {
+		return ErrMenuPathTaken
+	}
+	return nil
+}
+
+// collectSubtreeIDs returns id followed by the IDs of all its descendants
+func collectSubtreeIDs(db *gorm.DB, id uuid.UUID) ([]uuid.UUID, error) {
+	// db may carry scopes such as Unscoped; a session keeps them without
+	// letting conditions from one level leak into the next query
+	db = db.Session(&gorm.Session{})
+	ids := []uuid.UUID{id}
+	visited := map[uuid.UUID]bool{id: true}
+	level := []uuid.UUID{id}
+
+	for len(level) > 0 {
+		var childIDs []uuid.UUID
+		if err := db.Model(&models.Menu{}).Where("parent_id IN ?", level).Pluck("id", &childIDs).Error; err != nil {
+			return nil, err
+		}
+
+		next := make([]uuid.UUID, 0, len(childIDs))
+		for _, childID := range childIDs {
+			if visited[childID] {
+				continue
+			}
+			visited[childID] = true
+			next = append(next, childID)
+		}
+
+		ids = append(ids, next...)
+		level = next
+	}
+
+	return ids, nil
+}
+
+// ToggleMenu flips the is_active flag of the menu. Descendants keep their own
+// flag but are hidden along with it when the tree is read active-only.
+func (s *MenuService) ToggleMenu(id uuid.UUID) error {
+	defer InvalidateMenuCache()
+
+	result := s.db.Model(&models.Menu{}).
+		Where("id = ?", id).
+		Update("is_active", gorm.Expr("NOT is_active"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMenuNotFound
+	}
+	return nil
 }
 
 func (s *MenuService) MoveMenu(id uuid.UUID, newParentID *uuid.UUID) error {
+	defer InvalidateMenuCache()
+
+	if err := s.checkMove(id, newParentID); err != nil {
+		return err
+	}
+
+	return s.db.Model(&models.Menu{}).
+		Where("id = ?", id).
+		Updates(s.withUpdatedBy(map[string]interface{}{"parent_id": newParentID})).Error
+}
+
+// checkMove validates placing the menu and its subtree under newParentID:
+// the parent must exist outside the subtree and the subtree must still fit
+// within the depth limit
+func (s *MenuService) checkMove(id uuid.UUID, newParentID *uuid.UUID) error {
 	if newParentID != nil && *newParentID != uuid.Nil {
 		var parent models.Menu
 		if err := s.db.Where("id = ?", *newParentID).First(&parent).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("parent menu not found")
+				return ErrParentMenuNotFound
 			}
 			return err
 		}
+
+		subtree, err := collectSubtreeIDs(s.db, id)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(subtree, *newParentID) {
+			return ErrMenuCycle
+		}
 	}
 
-	return s.db.Model(&models.Menu{}).Where("id = ?", id).Update("parent_id", newParentID).Error
+	height, err := s.getSubtreeHeight(id)
+	if err != nil {
+		return err
+	}
+
+	return s.checkDepth(newParentID, height)
 }
 
-func (s *MenuService) getSiblingCount(parentID *uuid.UUID) (int64, error) {
-	var count int64
-	query := s.db.Model(&models.Menu{})
+// CloneSubtree deep-copies the menu and all of its descendants under
+// newParentID, or under the original's parent when newParentID is nil. The
+// copy is appended to the end of its sibling group and returned as a tree.
+// Paths are not copied since they must stay unique.
+func (s *MenuService) CloneSubtree(id uuid.UUID, newParentID *uuid.UUID) (*models.Menu, error) {
+	defer InvalidateMenuCache()
 
-	if parentID == nil {
-		query = query.Where("parent_id IS NULL")
-	} else {
-		query = query.Where("parent_id = ?", *parentID)
+	var source models.Menu
+	if err := s.db.Where("id = ?", id).First(&source).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMenuNotFound
+		}
+		return nil, err
 	}
 
-	if err := query.Count(&count).Error; err != nil {
-		return 0, err
+	targetParentID := source.ParentID
+	if newParentID != nil && *newParentID != uuid.Nil {
+		var parent models.Menu
+		if err := s.db.Where("id = ?", *newParentID).First(&parent).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrParentMenuNotFound
+			}
+			return nil, err
+		}
+		targetParentID = newParentID
+	}
+
+	height, err := s.getSubtreeHeight(id)
+	if err != nil {
+		return nil, err
 	}
 
-	return count, nil
+	if err := s.checkDepth(targetParentID, height); err != nil {
+		return nil, err
+	}
+
+	var clones []models.Menu
+	err = s.lockedTransaction(func(tx *gorm.DB) error {
+		siblings, err := lockSiblingGroup(tx, targetParentID)
+		if err != nil {
+			return err
+		}
+
+		ids, err := collectSubtreeIDs(tx, id)
+		if err != nil {
+			return err
+		}
+
+		// collectSubtreeIDs is breadth-first, so every parent is copied
+		// before its children
+		var originals []models.Menu
+		if err := tx.Where("id IN ?", ids).Find(&originals).Error; err != nil {
+			return err
+		}
+		byID := make(map[uuid.UUID]models.Menu, len(originals))
+		for _, menu := range originals {
+			byID[menu.ID] = menu
+		}
+
+		newIDs := make(map[uuid.UUID]uuid.UUID, len(ids))
+		clones = make([]models.Menu, 0, len(ids))
+		for _, originalID := range ids {
+			original := byID[originalID]
+			clone := models.Menu{
+				ID:         uuid.New(),
+				Title:      original.Title,
+				Icon:       original.Icon,
+				OrderIndex: original.OrderIndex,
+				IsActive:   original.IsActive,
+				CreatedBy:  s.actorID,
+				UpdatedBy:  s.actorID,
+			}
+
+			if originalID == id {
+				clone.ParentID = targetParentID
+				clone.Title += " (copy)"
+				clone.OrderIndex = len(siblings)
+			} else {
+				parentID := newIDs[*original.ParentID]
+				clone.ParentID = &parentID
+			}
+
+			if err := createMenuRow(tx, &clone); err != nil {
+				return err
+			}
+			newIDs[originalID] = clone.ID
+			clones = append(clones, clone)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	descendants := clones[1:]
+	sort.SliceStable(descendants, func(i, j int) bool {
+		return descendants[i].OrderIndex < descendants[j].OrderIndex
+	})
+
+	root := clones[0]
+	root.Children = s.buildChildren(root.ID, nil, descendants)
+	return &root, nil
+}
+
+// getDepth counts the ancestors a menu placed under parentID would have
+func (s *MenuService) getDepth(parentID *uuid.UUID) (int, error) {
+	depth := 0
+	visited := make(map[uuid.UUID]bool)
+
+	current := parentID
+	for current != nil && *current != uuid.Nil {
+		if visited[*current] {
+			return 0, errors.New("menu hierarchy contains a cycle")
+		}
+		visited[*current] = true
+
+		var menu models.Menu
+		if err := s.db.Select("id", "parent_id").Where("id = ?", *current).First(&menu).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, ErrParentMenuNotFound
+			}
+			return 0, err
+		}
+
+		depth++
+		current = menu.ParentID
+	}
+
+	return depth, nil
+}
+
+// getSubtreeHeight returns the number of levels in the subtree rooted at id,
+// stopping early once the configured maximum depth is exceeded
+func (s *MenuService) getSubtreeHeight(id uuid.UUID) (int, error) {
+	maxDepth := s.maxDepth()
+	height := 1
+	level := []uuid.UUID{id}
+
+	for height <= maxDepth {
+		var childIDs []uuid.UUID
+		if err := s.db.Model(&models.Menu{}).Where("parent_id IN ?", level).Pluck("id", &childIDs).Error; err != nil {
+			return 0, err
+		}
+		if len(childIDs) == 0 {
+			break
+		}
+		height++
+		level = childIDs
+	}
+
+	return height, nil
+}
+
+// checkDepth ensures a subtree of the given height fits under parentID
+func (s *MenuService) checkDepth(parentID *uuid.UUID, height int) error {
+	depth, err := s.getDepth(parentID)
+	if err != nil {
+		return err
+	}
+
+	if depth+height > s.maxDepth() {
+		return ErrMenuDepthExceeded
+	}
+
+	return nil
+}
+
+func (s *MenuService) maxDepth() int {
+	if config.AppConfig != nil && config.AppConfig.MenuMaxDepth > 0 {
+		return config.AppConfig.MenuMaxDepth
+	}
+	return defaultMenuMaxDepth
 }
 
 func (s *MenuService) ReorderMenu(id uuid.UUID, newIndex int, oldIndex *int) error {
+	defer InvalidateMenuCache()
+
+	if newIndex < 0 {
+		return errors.New("invalid target position: index cannot be negative")
+	}
+
+	return s.lockedTransaction(func(tx *gorm.DB) error {
+		return s.withTx(tx).reorderInGroup(id, newIndex, oldIndex)
+	})
+}
+
+// reorderInGroup moves the menu to newIndex within its sibling group and
+// shifts the siblings in between. It must run in a transaction: the group is
+// locked and positions are read again under the lock.
+func (s *MenuService) reorderInGroup(id uuid.UUID, newIndex int, oldIndex *int) error {
 	var menu models.Menu
 	if err := s.db.Where("id = ?", id).First(&menu).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("menu not found")
+			return ErrMenuNotFound
 		}
 		return err
 	}
 
-	siblingCount, err := s.getSiblingCount(menu.ParentID)
+	// Read the positions again under the lock: a reorder that committed
+	// meanwhile may have shifted them
+	siblings, err := lockSiblingGroup(s.db, menu.ParentID)
 	if err != nil {
 		return err
 	}
 
-	if newIndex < 0 {
-		return errors.New("invalid target position: index cannot be negative")
+	idx := slices.IndexFunc(siblings, func(m models.Menu) bool { return m.ID == id })
+	if idx < 0 {
+		return ErrMenuNotFound
 	}
+	menu.OrderIndex = siblings[idx].OrderIndex
+	siblingCount := int64(len(siblings))
 
 	if int64(newIndex) >= siblingCount {
 		newIndex = int(siblingCount) - 1
 	}
 
-	actualOldIndex := menu.OrderIndex
-	if oldIndex != nil {
-		actualOldIndex = *oldIndex
+	// A stale old_index, e.g. sent after a concurrent move, would shift
+	// the wrong range of siblings
+	if oldIndex != nil && (*oldIndex < 0 || int64(*oldIndex) >= siblingCount) {
+		return ErrOldIndexOutOfRange
+	}
+	if oldIndex != nil && *oldIndex != menu.OrderIndex {
+		return ErrStaleOldIndex
 	}
 
+	actualOldIndex := menu.OrderIndex
+
 	if actualOldIndex == newIndex {
 		return nil
 	}
 
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		baseQuery := tx.Model(&models.Menu{}).Where("id != ?", id)
+	baseQuery := s.db.Model(&models.Menu{}).Where("id != ?", id).Scopes(siblingsOf(menu.ParentID))
 
-		if menu.ParentID == nil {
-			baseQuery = baseQuery.Where("parent_id IS NULL")
-		} else {
-			baseQuery = baseQuery.Where("parent_id = ?", *menu.ParentID)
+	if actualOldIndex < newIndex {
+		if err := baseQuery.
+			Where("order_index > ?", actualOldIndex).
+			Where("order_index <= ?", newIndex).
+			Update("order_index", gorm.Expr("order_index - 1")).Error; err != nil {
+			return err
 		}
+	} else {
+		if err := baseQuery.
+			Where("order_index >= ?", newIndex).
+			Where("order_index < ?", actualOldIndex).
+			Update("order_index", gorm.Expr("order_index + 1")).Error; err != nil {
+			return err
+		}
+	}
 
-		if actualOldIndex < newIndex {
-			if err := baseQuery.
-				Where("order_index > ?", actualOldIndex).
-				Where("order_index <= ?", newIndex).
-				Update("order_index", gorm.Expr("order_index - 1")).Error; err != nil {
-				return err
-			}
-		} else {
-			if err := baseQuery.
-				Where("order_index >= ?", newIndex).
-				Where("order_index < ?", actualOldIndex).
-				Update("order_index", gorm.Expr("order_index + 1")).Error; err != nil {
-				return err
-			}
+	if err := s.db.Model(&models.Menu{}).
+		Where("id = ?", id).
+		Updates(s.withUpdatedBy(map[string]interface{}{"order_index": newIndex})).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// lockSiblingGroup locks the parent row, which also serializes inserts into
+// an empty group, and then every menu under it, in id order so concurrent
+// writers cannot deadlock. It returns the id and order_index of the siblings.
+func lockSiblingGroup(tx *gorm.DB, parentID *uuid.UUID) ([]models.Menu, error) {
+	if parentID != nil {
+		var parent models.Menu
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", *parentID).
+			Find(&parent).Error; err != nil {
+			return nil, err
 		}
+	}
+
+	var siblings []models.Menu
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "order_index").
+		Scopes(siblingsOf(parentID)).
+		Order("id").
+		Find(&siblings).Error; err != nil {
+		return nil, err
+	}
+	return siblings, nil
+}
+
+// sqliteWriteMu serializes lockedTransaction on SQLite, whose driver drops
+// FOR UPDATE because it has no row locks
+var sqliteWriteMu sync.Mutex
+
+// lockedTransaction runs fn in a transaction for rewrites that lock rows
+// with FOR UPDATE; on SQLite the transactions run one at a time instead
+func (s *MenuService) lockedTransaction(fn func(tx *gorm.DB) error) error {
+	if s.db.Dialector.Name() == "sqlite" {
+		sqliteWriteMu.Lock()
+		defer sqliteWriteMu.Unlock()
+	}
+	return s.db.Transaction(fn)
+}
 
-		if err := tx.Model(&models.Menu{}).Where("id = ?", id).Update("order_index", newIndex).Error; err != nil {
+// ReorderSiblings assigns order_index by position in orderedIDs, which must
+// contain exactly the current members of the parent's sibling group
+func (s *MenuService) ReorderSiblings(parentID *uuid.UUID, orderedIDs []uuid.UUID) error {
+	defer InvalidateMenuCache()
+
+	return s.lockedTransaction(func(tx *gorm.DB) error {
+		siblings, err := lockSiblingGroup(tx, parentID)
+		if err != nil {
 			return err
 		}
 
+		if len(siblings) != len(orderedIDs) {
+			return ErrSiblingSetMismatch
+		}
+
+		current := make(map[uuid.UUID]bool, len(siblings))
+		for _, sibling := range siblings {
+			current[sibling.ID] = true
+		}
+		for _, id := range orderedIDs {
+			if !current[id] {
+				return ErrSiblingSetMismatch
+			}
+		}
+
+		for index, id := range orderedIDs {
+			if err := tx.Model(&models.Menu{}).
+				Where("id = ?", id).
+				Updates(s.withUpdatedBy(map[string]interface{}{"order_index": index})).Error; err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
 
+// GetSiblings returns the menus under parentID ordered by order_index
+func (s *MenuService) GetSiblings(parentID *uuid.UUID) ([]models.Menu, error) {
+	var menus []models.Menu
+	if err := s.db.Scopes(siblingsOf(parentID)).Order("order_index ASC").Find(&menus).Error; err != nil {
+		return nil, err
+	}
+	return menus, nil
+}
+
+// siblingsOf scopes a query to the sibling group under parentID
+func siblingsOf(parentID *uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if parentID == nil {
+			return db.Where("parent_id IS NULL")
+		}
+		return db.Where("parent_id = ?", *parentID)
+	}
+}
+
 func (s *MenuService) buildChildren(parentID uuid.UUID, menuMap map[uuid.UUID]*models.Menu, allMenus []models.Menu) []models.Menu {
 	children := make([]models.Menu, 0)
 
@@ -207,9 +1033,158 @@ func (s *MenuService) buildChildren(parentID uuid.UUID, menuMap map[uuid.UUID]*m
 	return children
 }
 
-func (s *MenuService) GetMenuTree() ([]models.Menu, error) {
+// ListMenus returns one page of menus as a flat list, matching the search
+// term against title and path, together with the total number of matches
+func (s *MenuService) ListMenus(params query.ListParams) ([]models.Menu, int64, error) {
+	base := s.db.Model(&models.Menu{})
+	if params.Search != "" {
+		pattern := "%" + params.Search + "%"
+		conds := s.db.Session(&gorm.Session{NewDB: true})
+		base = base.Where(database.CaseInsensitiveLike(conds, "title", pattern).
+			Or(database.CaseInsensitiveLike(conds, "path", pattern)))
+	}
+
+	var total int64
+	if err := params.Filter(base.Session(&gorm.Session{})).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var menus []models.Menu
+	if err := params.Apply(base.Session(&gorm.Session{})).Find(&menus).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return menus, total, nil
+}
+
+// GetOrphans returns live menus whose parent_id does not resolve to a live
+// menu, which leaves them unreachable from the tree
+func (s *MenuService) GetOrphans() ([]models.Menu, error) {
+	var orphans []models.Menu
+	if err := s.db.Table("menus AS child").
+		Select("child.*").
+		Joins("LEFT JOIN menus AS parent ON parent.id = child.parent_id AND parent.deleted_at IS NULL").
+		Where("child.parent_id IS NOT NULL AND parent.id IS NULL AND child.deleted_at IS NULL").
+		Order("child.created_at ASC").
+		Find(&orphans).Error; err != nil {
+		return nil, err
+	}
+	return orphans, nil
+}
+
+// AssignIcons sets the icon of every listed menu in one transaction. IDs that
+// do not match a live menu are skipped and returned in sorted order.
+func (s *MenuService) AssignIcons(assignments map[uuid.UUID]string) (int64, []uuid.UUID, error) {
+	defer InvalidateMenuCache()
+
+	ids := make([]uuid.UUID, 0, len(assignments))
+	for id := range assignments {
+		ids = append(ids, id)
+	}
+
+	var updated int64
+	unknown := make([]uuid.UUID, 0)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var existing []uuid.UUID
+		if err := tx.Model(&models.Menu{}).Where("id IN ?", ids).Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+		found := make(map[uuid.UUID]bool, len(existing))
+		for _, id := range existing {
+			found[id] = true
+		}
+
+		for _, id := range ids {
+			if !found[id] {
+				unknown = append(unknown, id)
+				continue
+			}
+			result := tx.Model(&models.Menu{}).
+				Where("id = ?", id).
+				Updates(s.withUpdatedBy(map[string]interface{}{"icon": assignments[id]}))
+			if result.Error != nil {
+				return result.Error
+			}
+			updated += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	slices.SortFunc(unknown, func(a, b uuid.UUID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return updated, unknown, nil
+}
+
+// GetSelectOptions flattens the tree in pre-order, keeping the first maxDepth
+// levels; a maxDepth of 0 keeps every level
+func (s *MenuService) GetSelectOptions(maxDepth int) ([]models.MenuSelectOption, error) {
+	tree, err := s.GetMenuTree(TreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]models.MenuSelectOption, 0)
+	var walk func(menus []models.Menu, depth int)
+	walk = func(menus []models.Menu, depth int) {
+		if maxDepth > 0 && depth >= maxDepth {
+			return
+		}
+		for _, menu := range menus {
+			options = append(options, models.MenuSelectOption{
+				ID:       menu.ID,
+				Title:    menu.Title,
+				Depth:    depth,
+				ParentID: menu.ParentID,
+			})
+			walk(menu.Children, depth+1)
+		}
+	}
+	walk(tree, 0)
+
+	return options, nil
+}
+
+// GetMenuTree returns the menus selected by opts as a tree, from the shared
+// cache when it is enabled and holds a fresh copy
+func (s *MenuService) GetMenuTree(opts TreeOptions) ([]models.Menu, error) {
+	ttl := menuCacheTTL()
+	if ttl <= 0 || inTransaction(s.db) {
+		return s.buildMenuTree(opts)
+	}
+
+	if tree, ok := treeCache.get(opts); ok {
+		return tree, nil
+	}
+
+	generation := treeCache.currentGeneration()
+	tree, err := s.buildMenuTree(opts)
+	if err != nil {
+		return nil, err
+	}
+	treeCache.set(opts, tree, generation, ttl)
+	return tree, nil
+}
+
+// buildMenuTree loads the menus selected by opts and assembles them into a
+// tree
+func (s *MenuService) buildMenuTree(opts TreeOptions) ([]models.Menu, error) {
+	query := s.db
+	if opts.IncludeDeleted {
+		query = query.Unscoped()
+	}
+
+	// Descendants of an inactive menu lose their parent in the result and
+	// are never attached, so filtering here prunes whole subtrees
+	if opts.ActiveOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
 	var allMenus []models.Menu
-	if err := s.db.Order("order_index ASC").Find(&allMenus).Error; err != nil {
+	if err := query.Order("order_index ASC").Find(&allMenus).Error; err != nil {
 		return nil, err
 	}
 
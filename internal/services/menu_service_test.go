@@ -0,0 +1,281 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+
+	"gorm.io/gorm"
+)
+
+func siblingIndices(t *testing.T, db *gorm.DB) map[string]int {
+	t.Helper()
+	var menus []models.Menu
+	if err := db.Where("parent_id IS NULL").Find(&menus).Error; err != nil {
+		t.Fatalf("Failed to load menus: %v", err)
+	}
+	indices := make(map[string]int, len(menus))
+	for _, m := range menus {
+		indices[m.Title] = m.OrderIndex
+	}
+	return indices
+}
+
+func TestCreateMenu_ShiftsAndClampsIndexes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	s := NewMenuService(db)
+
+	steps := []struct {
+		title string
+		index int
+	}{
+		{"A", 0},
+		{"B", 10},
+		{"C", 1},
+		{"D", 0},
+	}
+	for _, step := range steps {
+		menu := &models.Menu{Title: step.title, OrderIndex: step.index}
+		if err := s.CreateMenu(menu); err != nil {
+			t.Fatalf("Failed to create %s: %v", step.title, err)
+		}
+	}
+
+	expected := map[string]int{"D": 0, "A": 1, "C": 2, "B": 3}
+	testutil.AssertEqual(t, expected, siblingIndices(t, db))
+}
+
+func TestCreateMenu_ClampsToAppendIndex(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	s := NewMenuService(db)
+
+	parent := testutil.CreateMenuFixture(db, "Parent", nil, 0)
+	testutil.CreateMenuFixture(db, "Child 0", &parent.ID, 0)
+	testutil.CreateMenuFixture(db, "Child 1", &parent.ID, 1)
+
+	menu := &models.Menu{Title: "Appended", ParentID: &parent.ID, OrderIndex: 99}
+	if err := s.CreateMenu(menu); err != nil {
+		t.Fatalf("Failed to create menu: %v", err)
+	}
+
+	testutil.AssertEqual(t, 2, menu.OrderIndex)
+
+	var stored models.Menu
+	db.Where("id = ?", menu.ID).First(&stored)
+	testutil.AssertEqual(t, 2, stored.OrderIndex)
+	testutil.AssertEqual(t, parent.ID, *stored.ParentID)
+}
+
+func TestCreateMenu_KeepsInactiveFlag(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	s := NewMenuService(db)
+
+	menu := &models.Menu{Title: "Hidden", IsActive: false}
+	if err := s.CreateMenu(menu); err != nil {
+		t.Fatalf("Failed to create menu: %v", err)
+	}
+
+	var stored models.Menu
+	db.Where("id = ?", menu.ID).First(&stored)
+	testutil.AssertEqual(t, false, stored.IsActive)
+}
+
+// countStatements registers callbacks that count every statement sent to the DB
+func countStatements(db *gorm.DB) *int64 {
+	var count int64
+	inc := func(*gorm.DB) { atomic.AddInt64(&count, 1) }
+	db.Callback().Create().After("gorm:create").Register("test:count_create", inc)
+	db.Callback().Query().After("gorm:query").Register("test:count_query", inc)
+	db.Callback().Update().After("gorm:update").Register("test:count_update", inc)
+	db.Callback().Raw().After("gorm:raw").Register("test:count_raw", inc)
+	db.Callback().Row().After("gorm:row").Register("test:count_row", inc)
+	return &count
+}
+
+func BenchmarkCreateMenu(b *testing.B) {
+	positions := map[string]func(i int) int{
+		"prepend": func(int) int { return 0 },
+		"append":  func(i int) int { return i },
+	}
+
+	for position, indexFor := range positions {
+		b.Run(position, func(b *testing.B) {
+			db := testutil.SetupTestDB(b)
+			defer testutil.TeardownTestDB(db)
+
+			s := NewMenuService(db)
+			statements := countStatements(db)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := s.CreateMenu(&models.Menu{Title: "Bench", OrderIndex: indexFor(i)}); err != nil {
+					b.Fatalf("Failed to create menu: %v", err)
+				}
+			}
+			b.ReportMetric(float64(atomic.LoadInt64(statements))/float64(b.N), "stmts/op")
+		})
+	}
+}
+
+func TestReorderMenu_ConcurrentReordersKeepSequence(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	s := NewMenuService(db)
+
+	const siblings = 6
+	menus := make([]*models.Menu, siblings)
+	for i := range menus {
+		menus[i] = testutil.CreateMenuFixture(db, fmt.Sprintf("Menu %d", i), nil, i)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4*siblings)
+	for i := range 4 * siblings {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.ReorderMenu(menus[i%siblings].ID, (i*5)%siblings, nil)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Reorder failed: %v", err)
+		}
+	}
+
+	indices := make([]int, 0, siblings)
+	for _, index := range siblingIndices(t, db) {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	testutil.AssertEqual(t, []int{0, 1, 2, 3, 4, 5}, indices)
+}
+
+func TestCreateMenu_ConcurrentCreatesAtSameIndex(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(db)
+
+	s := NewMenuService(db)
+
+	parent := testutil.CreateMenuFixture(db, "Parent", nil, 0)
+	testutil.CreateMenuFixture(db, "Existing", &parent.ID, 0)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, title := range []string{"First", "Second"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.CreateMenu(&models.Menu{Title: title, ParentID: &parent.ID, OrderIndex: 0})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	var indices []int
+	if err := db.Model(&models.Menu{}).
+		Where("parent_id = ?", parent.ID).
+		Order("order_index").
+		Pluck("order_index", &indices).Error; err != nil {
+		t.Fatalf("Failed to load indices: %v", err)
+	}
+	testutil.AssertEqual(t, []int{0, 1, 2}, indices)
+}
+
+// testBackends returns the databases a test can run against; postgres and
+// mysql skip themselves unless TEST_DB names them and Docker is available
+func testBackends() map[string]func(t *testing.T) *gorm.DB {
+	return map[string]func(t *testing.T) *gorm.DB{
+		"sqlite":   func(t *testing.T) *gorm.DB { return testutil.SetupTestDB(t) },
+		"postgres": testutil.SetupPostgresTestDB,
+		"mysql":    testutil.SetupMySQLTestDB,
+	}
+}
+
+func TestMenuPathUniqueIndex_Backends(t *testing.T) {
+	for name, setup := range testBackends() {
+		t.Run(name, func(t *testing.T) {
+			db := setup(t)
+			defer testutil.TeardownTestDB(db)
+
+			path := "/reports"
+			first := models.Menu{Title: "First", Path: &path}
+			if err := db.Create(&first).Error; err != nil {
+				t.Fatalf("Failed to create menu: %v", err)
+			}
+			if err := db.Create(&models.Menu{Title: "Second", Path: &path, OrderIndex: 1}).Error; err == nil {
+				t.Fatal("Expected a second live menu with the same path to be rejected")
+			}
+
+			// Menus without a path are not constrained
+			for i := 0; i < 2; i++ {
+				if err := db.Create(&models.Menu{Title: "Parent", OrderIndex: 2 + i}).Error; err != nil {
+					t.Fatalf("Failed to create menu without path: %v", err)
+				}
+			}
+
+			// A soft-deleted menu frees its path
+			if err := db.Delete(&first).Error; err != nil {
+				t.Fatalf("Failed to soft delete menu: %v", err)
+			}
+			if err := db.Create(&models.Menu{Title: "Replacement", Path: &path}).Error; err != nil {
+				t.Fatalf("Expected the path of a soft-deleted menu to be reusable: %v", err)
+			}
+		})
+	}
+}
+
+func TestReorderMenu_Backends(t *testing.T) {
+	for name, setup := range testBackends() {
+		t.Run(name, func(t *testing.T) {
+			db := setup(t)
+			defer testutil.TeardownTestDB(db)
+
+			s := NewMenuService(db)
+			parent := testutil.CreateMenuFixture(db, "Parent", nil, 0)
+			a := testutil.CreateMenuFixture(db, "A", &parent.ID, 0)
+			testutil.CreateMenuFixture(db, "B", &parent.ID, 1)
+			testutil.CreateMenuFixture(db, "C", &parent.ID, 2)
+			testutil.CreateMenuFixture(db, "Root", nil, 1)
+
+			if err := s.ReorderMenu(a.ID, 2, nil); err != nil {
+				t.Fatalf("Failed to reorder menu: %v", err)
+			}
+
+			var children []models.Menu
+			if err := db.Where("parent_id = ?", parent.ID).Order("order_index").Find(&children).Error; err != nil {
+				t.Fatalf("Failed to load children: %v", err)
+			}
+			titles := make([]string, len(children))
+			for i, child := range children {
+				titles[i] = child.Title
+				testutil.AssertEqual(t, i, child.OrderIndex)
+			}
+			testutil.AssertEqual(t, []string{"B", "C", "A"}, titles)
+
+			// Root menus are a separate sibling group and keep their order
+			testutil.AssertEqual(t, map[string]int{"Parent": 0, "Root": 1}, siblingIndices(t, db))
+		})
+	}
+}
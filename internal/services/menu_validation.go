@@ -0,0 +1,199 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/google/uuid"
+)
+
+// ValidateTree checks the live menus for cycles, orphans, gaps in sibling
+// order indexes, duplicate paths and depth beyond the configured limit.
+// It only reads; fixing the violations is left to the caller.
+func (s *MenuService) ValidateTree() (*models.MenuTreeReport, error) {
+	var menus []models.Menu
+	if err := s.db.Order("created_at ASC").Find(&menus).Error; err != nil {
+		return nil, err
+	}
+
+	orphans, err := s.GetOrphans()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]models.Menu, len(menus))
+	for _, menu := range menus {
+		byID[menu.ID] = menu
+	}
+
+	violations := make([]models.MenuTreeViolation, 0)
+	violations = append(violations, cycleViolations(menus, byID)...)
+	if len(orphans) > 0 {
+		ids := make([]uuid.UUID, 0, len(orphans))
+		for _, orphan := range orphans {
+			ids = append(ids, orphan.ID)
+		}
+		violations = append(violations, models.MenuTreeViolation{
+			Rule:    "orphan",
+			Message: "parent_id does not point to an existing menu",
+			MenuIDs: ids,
+		})
+	}
+	violations = append(violations, siblingGapViolations(menus)...)
+	violations = append(violations, duplicatePathViolations(menus)...)
+	violations = append(violations, s.depthViolations(menus, byID)...)
+
+	return &models.MenuTreeReport{
+		Valid:      len(violations) == 0,
+		Violations: violations,
+	}, nil
+}
+
+// cycleViolations reports each parent_id loop once, listing its members
+func cycleViolations(menus []models.Menu, byID map[uuid.UUID]models.Menu) []models.MenuTreeViolation {
+	violations := make([]models.MenuTreeViolation, 0)
+	reported := make(map[uuid.UUID]bool)
+
+	for _, menu := range menus {
+		path := make([]uuid.UUID, 0)
+		position := make(map[uuid.UUID]int)
+
+		current, ok := menu, true
+		for ok && !reported[current.ID] {
+			if start, seen := position[current.ID]; seen {
+				cycle := path[start:]
+				for _, id := range cycle {
+					reported[id] = true
+				}
+				violations = append(violations, models.MenuTreeViolation{
+					Rule:    "cycle",
+					Message: "parent_id references form a loop",
+					MenuIDs: cycle,
+				})
+				break
+			}
+			position[current.ID] = len(path)
+			path = append(path, current.ID)
+
+			if current.ParentID == nil {
+				break
+			}
+			current, ok = byID[*current.ParentID]
+		}
+	}
+
+	return violations
+}
+
+// siblingGapViolations reports sibling groups whose order indexes are not
+// exactly 0..n-1
+func siblingGapViolations(menus []models.Menu) []models.MenuTreeViolation {
+	groups := make(map[uuid.UUID][]models.Menu)
+	for _, menu := range menus {
+		var parentID uuid.UUID
+		if menu.ParentID != nil {
+			parentID = *menu.ParentID
+		}
+		groups[parentID] = append(groups[parentID], menu)
+	}
+
+	violations := make([]models.MenuTreeViolation, 0)
+	for parentID, siblings := range groups {
+		sort.SliceStable(siblings, func(i, j int) bool {
+			return siblings[i].OrderIndex < siblings[j].OrderIndex
+		})
+
+		contiguous := true
+		for index, sibling := range siblings {
+			if sibling.OrderIndex != index {
+				contiguous = false
+				break
+			}
+		}
+		if contiguous {
+			continue
+		}
+
+		ids := make([]uuid.UUID, 0, len(siblings))
+		for _, sibling := range siblings {
+			ids = append(ids, sibling.ID)
+		}
+		parent := "the root"
+		if parentID != uuid.Nil {
+			parent = parentID.String()
+		}
+		violations = append(violations, models.MenuTreeViolation{
+			Rule:    "sibling_gap",
+			Message: fmt.Sprintf("order_index values under %s are not 0..%d", parent, len(siblings)-1),
+			MenuIDs: ids,
+		})
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].Message < violations[j].Message
+	})
+	return violations
+}
+
+// duplicatePathViolations reports every path shared by more than one menu
+func duplicatePathViolations(menus []models.Menu) []models.MenuTreeViolation {
+	owners := make(map[string][]uuid.UUID)
+	paths := make([]string, 0)
+	for _, menu := range menus {
+		if menu.Path == nil {
+			continue
+		}
+		if _, seen := owners[*menu.Path]; !seen {
+			paths = append(paths, *menu.Path)
+		}
+		owners[*menu.Path] = append(owners[*menu.Path], menu.ID)
+	}
+
+	violations := make([]models.MenuTreeViolation, 0)
+	for _, path := range paths {
+		if len(owners[path]) < 2 {
+			continue
+		}
+		violations = append(violations, models.MenuTreeViolation{
+			Rule:    "duplicate_path",
+			Message: fmt.Sprintf("path %q is used by %d menus", path, len(owners[path])),
+			MenuIDs: owners[path],
+		})
+	}
+	return violations
+}
+
+// depthViolations reports menus nested deeper than the configured limit.
+// Walks stop at missing parents and loops, which are reported separately.
+func (s *MenuService) depthViolations(menus []models.Menu, byID map[uuid.UUID]models.Menu) []models.MenuTreeViolation {
+	maxDepth := s.maxDepth()
+	ids := make([]uuid.UUID, 0)
+
+	for _, menu := range menus {
+		depth := 1
+		visited := map[uuid.UUID]bool{menu.ID: true}
+		current := menu
+		for current.ParentID != nil {
+			parent, ok := byID[*current.ParentID]
+			if !ok || visited[parent.ID] {
+				break
+			}
+			visited[parent.ID] = true
+			depth++
+			current = parent
+		}
+		if depth > maxDepth {
+			ids = append(ids, menu.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return []models.MenuTreeViolation{{
+		Rule:    "depth_exceeded",
+		Message: fmt.Sprintf("menus are nested deeper than the limit of %d levels", maxDepth),
+		MenuIDs: ids,
+	}}
+}
@@ -1,8 +1,7 @@
 package testutil
 
 import (
-	"io"
-	"log"
+	"log/slog"
 	"testing"
 
 	"github.com/andhikadk/stk-test-be/internal/utils"
@@ -15,7 +14,7 @@ import (
 	_ "modernc.org/sqlite"
 )
 
-func SetupTestDB(t *testing.T) *gorm.DB {
+func SetupTestDB(t testing.TB) *gorm.DB {
 	db, err := gorm.Open(sqlite.Dialector{
 		DriverName: "sqlite",
 		DSN:        "file::memory:?cache=shared",
@@ -26,7 +25,7 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to connect test database: %v", err)
 	}
 
-	if err := db.AutoMigrate(&models.Menu{}); err != nil {
+	if err := db.AutoMigrate(&models.Menu{}, &models.MenuPreset{}); err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
 
@@ -41,6 +40,5 @@ func TeardownTestDB(db *gorm.DB) {
 }
 
 func InitTestLogger() {
-	utils.InfoLogger = log.New(io.Discard, "[INFO] ", log.Ldate|log.Ltime|log.Lshortfile)
-	utils.ErrorLogger = log.New(io.Discard, "[ERROR] ", log.Ldate|log.Ltime|log.Lshortfile)
+	utils.Logger = slog.New(slog.DiscardHandler)
 }
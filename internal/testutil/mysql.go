@@ -0,0 +1,74 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/internal/database"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// mysqlImage is the MySQL server the migrations in migrations/mysql target
+const mysqlImage = "mysql:8.4"
+
+// SetupMySQLTestDB starts a throwaway MySQL container, applies the SQL
+// migrations from migrations/mysql and returns a connection to it. The
+// container is removed when the test ends. Tests are skipped unless
+// TEST_DB=mysql, and when Docker is not available.
+func SetupMySQLTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	if os.Getenv("TEST_DB") != "mysql" {
+		t.Skip("set TEST_DB=mysql to run against MySQL")
+	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	container, err := testcontainers.Run(ctx, mysqlImage,
+		testcontainers.WithExposedPorts("3306/tcp"),
+		testcontainers.WithEnv(map[string]string{
+			"MYSQL_ROOT_PASSWORD": "stk",
+			"MYSQL_DATABASE":      "stk_test",
+			"MYSQL_USER":          "stk",
+			"MYSQL_PASSWORD":      "stk",
+		}),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("port: 3306  MySQL Community Server"),
+			wait.ForListeningPort("3306/tcp"),
+		),
+	)
+	testcontainers.CleanupContainer(t, container)
+	if err != nil {
+		t.Fatalf("Failed to start MySQL container: %v", err)
+	}
+
+	endpoint, err := container.PortEndpoint(ctx, "3306/tcp", "")
+	if err != nil {
+		t.Fatalf("Failed to get MySQL endpoint: %v", err)
+	}
+
+	// Same parameters as the DB_PARAMS default
+	dsn := fmt.Sprintf("stk:stk@tcp(%s)/stk_test?charset=utf8mb4&parseTime=True&loc=UTC&multiStatements=true", endpoint)
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to MySQL: %v", err)
+	}
+
+	if err := database.MigrateFromFS(db, os.DirFS(moduleRoot(t))); err != nil {
+		t.Fatalf("Failed to migrate MySQL: %v", err)
+	}
+
+	return db
+}
@@ -0,0 +1,86 @@
+package testutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/internal/database"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// postgresImage is the server the production database runs
+const postgresImage = "postgres:16-alpine"
+
+// SetupPostgresTestDB starts a throwaway Postgres container, applies the SQL
+// migrations from migrations/ and returns a connection to it. The container
+// is removed when the test ends. Tests are skipped unless TEST_DB=postgres,
+// and when Docker is not available.
+func SetupPostgresTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	if os.Getenv("TEST_DB") != "postgres" {
+		t.Skip("set TEST_DB=postgres to run against Postgres")
+	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	container, err := tcpostgres.Run(ctx, postgresImage,
+		tcpostgres.WithDatabase("stk_test"),
+		tcpostgres.WithUsername("stk"),
+		tcpostgres.WithPassword("stk"),
+		tcpostgres.BasicWaitStrategies(),
+	)
+	testcontainers.CleanupContainer(t, container)
+	if err != nil {
+		t.Fatalf("Failed to start Postgres container: %v", err)
+	}
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("Failed to get Postgres connection string: %v", err)
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to Postgres: %v", err)
+	}
+
+	if err := database.MigrateFromFS(db, os.DirFS(moduleRoot(t))); err != nil {
+		t.Fatalf("Failed to migrate Postgres: %v", err)
+	}
+
+	return db
+}
+
+// moduleRoot returns the directory holding go.mod, which the migrations
+// directory sits next to
+func moduleRoot(t *testing.T) string {
+	t.Helper()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			t.Fatal("go.mod not found above the test directory")
+		}
+		dir = parent
+	}
+}
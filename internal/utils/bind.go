@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"github.com/andhikadk/stk-test-be/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Validator is implemented by request DTOs that check their own fields
+type Validator interface {
+	Validate() error
+}
+
+// BindError is the 400 returned by BindAndValidate. Message and Code are
+// the response message and error code ("Invalid request body" or
+// "Validation failed") and Err the underlying parse or validation error,
+// which the error middleware reports as the response's error detail.
+type BindError struct {
+	Status  int
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *BindError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// BindAndValidate parses the JSON body into a T and validates it. On failure
+// it returns a *BindError for the handler to return as is, e.g.
+//
+//	req, err := utils.BindAndValidate[dto.CreateMenuRequest](c)
+//	if err != nil {
+//		return err
+//	}
+func BindAndValidate[T any, PT interface {
+	*T
+	Validator
+}](c *fiber.Ctx) (T, error) {
+	var req T
+
+	if err := c.BodyParser(&req); err != nil {
+		return req, &BindError{
+			Status:  fiber.StatusBadRequest,
+			Code:    models.CodeInvalidRequestBody,
+			Message: "Invalid request body",
+			Err:     err,
+		}
+	}
+
+	if err := PT(&req).Validate(); err != nil {
+		Warn(c.UserContext(), "validation failed", "method", c.Method(), "path", c.Path(), "error", err)
+		return req, &BindError{
+			Status:  fiber.StatusBadRequest,
+			Code:    models.CodeValidationFailed,
+			Message: "Validation failed",
+			Err:     err,
+		}
+	}
+
+	return req, nil
+}
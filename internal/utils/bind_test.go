@@ -0,0 +1,95 @@
+package utils_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/dto"
+	"github.com/andhikadk/stk-test-be/internal/middleware"
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+	"github.com/andhikadk/stk-test-be/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type bindTestRequest struct {
+	Name string `json:"name"`
+}
+
+func (r *bindTestRequest) Validate() error {
+	var v dto.ValidationError
+	if r.Name == "" {
+		v.Add("name", "name is required")
+	}
+	return v.Err()
+}
+
+func setupBindApp() *fiber.App {
+	testutil.InitTestLogger()
+
+	app := fiber.New()
+	app.Use(middleware.ErrorHandlingMiddleware())
+	app.Post("/", func(c *fiber.Ctx) error {
+		req, err := utils.BindAndValidate[bindTestRequest](c)
+		if err != nil {
+			return err
+		}
+		return c.JSON(models.APIResponse{Status: fiber.StatusOK, Message: "ok", Data: req.Name})
+	})
+	return app
+}
+
+func postJSON(t *testing.T, app *fiber.App, body string) models.APIResponse {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+	testutil.AssertEqual(t, resp.StatusCode, result.Status)
+	return result
+}
+
+func TestBindAndValidate_Valid(t *testing.T) {
+	result := postJSON(t, setupBindApp(), `{"name":"menu"}`)
+
+	testutil.AssertEqual(t, fiber.StatusOK, result.Status)
+	testutil.AssertEqual(t, "menu", result.Data)
+}
+
+func TestBindAndValidate_MalformedBody(t *testing.T) {
+	result := postJSON(t, setupBindApp(), `{"name":`)
+
+	testutil.AssertEqual(t, fiber.StatusBadRequest, result.Status)
+	testutil.AssertEqual(t, "Invalid request body", result.Message)
+	testutil.AssertEqual(t, models.CodeInvalidRequestBody, result.Code)
+	testutil.AssertNotEqual(t, "", result.Error)
+	testutil.AssertNil(t, result.Errors)
+}
+
+func TestBindAndValidate_InvalidPayload(t *testing.T) {
+	result := postJSON(t, setupBindApp(), `{"name":""}`)
+
+	testutil.AssertEqual(t, fiber.StatusBadRequest, result.Status)
+	testutil.AssertEqual(t, "Validation failed", result.Message)
+	testutil.AssertEqual(t, models.CodeValidationFailed, result.Code)
+	testutil.AssertEqual(t, "name is required", result.Error)
+	testutil.AssertEqual(t, "name is required", result.Errors["name"])
+}
+
+func TestBindError_Unwrap(t *testing.T) {
+	cause := errors.New("boom")
+	err := error(&utils.BindError{Status: fiber.StatusBadRequest, Message: "Validation failed", Err: cause})
+
+	testutil.AssertEqual(t, true, errors.Is(err, cause))
+	testutil.AssertEqual(t, "boom", err.Error())
+}
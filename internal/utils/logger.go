@@ -1,28 +1,138 @@
 package utils
 
 import (
-	"log"
+	"context"
+	"io"
+	"log/slog"
 	"os"
-)
+	"path/filepath"
+	"strings"
 
-var (
-	InfoLogger  *log.Logger
-	ErrorLogger *log.Logger
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func InitLogger() error {
-	if err := os.MkdirAll("logs", 0755); err != nil {
-		return err
+// Logger is the application logger. It logs to stderr until InitLogger
+// points it at the log file.
+var Logger = slog.New(contextHandler{slog.Default().Handler()})
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, if any
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHandler adds the request ID from the log call's context to every
+// record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// DefaultLogFile is where InitLogger writes when LogOptions.Filename is empty
+const DefaultLogFile = "logs/app.log"
+
+// LogOptions configures InitLogger. The log file is rotated once it reaches
+// MaxSizeMB; rotated files beyond MaxBackups or older than MaxAgeDays are
+// removed, and a zero value for either keeps them.
+type LogOptions struct {
+	Level      string
+	JSON       bool
+	Console    bool // also write to stdout, for development
+	Filename   string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+}
+
+// InitLogger sends logs to a size-rotated log file at the given level, as
+// JSON lines or, for development, as human-readable text
+func InitLogger(opts LogOptions) error {
+	filename := opts.Filename
+	if filename == "" {
+		filename = DefaultLogFile
 	}
 
-	logFile, err := os.OpenFile("logs/app.log",
-		os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
 		return err
 	}
 
-	InfoLogger = log.New(logFile, "[INFO] ", log.Ldate|log.Ltime|log.Lshortfile)
-	ErrorLogger = log.New(logFile, "[ERROR] ", log.Ldate|log.Ltime|log.Lshortfile)
+	var w io.Writer = &lumberjack.Logger{
+		Filename:   filename,
+		MaxSize:    opts.MaxSizeMB,
+		MaxBackups: opts.MaxBackups,
+		MaxAge:     opts.MaxAgeDays,
+		Compress:   opts.Compress,
+	}
+	if opts.Console {
+		w = io.MultiWriter(os.Stdout, w)
+	}
 
+	Logger = NewLogger(w, ParseLogLevel(opts.Level), opts.JSON)
 	return nil
 }
+
+// NewLogger creates a logger writing to w with a JSON or text handler; lines
+// logged with a request context carry its request_id
+func NewLogger(w io.Writer, level slog.Level, json bool) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if json {
+		return slog.New(contextHandler{slog.NewJSONHandler(w, opts)})
+	}
+	return slog.New(contextHandler{slog.NewTextHandler(w, opts)})
+}
+
+// ParseLogLevel maps a LOG_LEVEL value to a slog level, defaulting to info
+func ParseLogLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Debug logs msg at debug level with the given key-value attributes
+func Debug(ctx context.Context, msg string, attrs ...any) {
+	Logger.DebugContext(ctx, msg, attrs...)
+}
+
+// Info logs msg at info level with the given key-value attributes
+func Info(ctx context.Context, msg string, attrs ...any) {
+	Logger.InfoContext(ctx, msg, attrs...)
+}
+
+// Warn logs msg at warn level with the given key-value attributes
+func Warn(ctx context.Context, msg string, attrs ...any) {
+	Logger.WarnContext(ctx, msg, attrs...)
+}
+
+// Error logs msg at error level with the given key-value attributes
+func Error(ctx context.Context, msg string, attrs ...any) {
+	Logger.ErrorContext(ctx, msg, attrs...)
+}
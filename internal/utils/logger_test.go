@@ -0,0 +1,96 @@
+package utils_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andhikadk/stk-test-be/internal/utils"
+)
+
+func TestLogger_JSONOutput(t *testing.T) {
+	var buf bytes.Buffer
+	original := utils.Logger
+	utils.Logger = utils.NewLogger(&buf, slog.LevelInfo, true)
+	defer func() { utils.Logger = original }()
+
+	utils.Error(context.Background(), "request failed", "handler", "GetMenu", "error", errors.New("boom"))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a JSON log line, got %q: %v", buf.String(), err)
+	}
+
+	expected := map[string]string{
+		"level":   "ERROR",
+		"msg":     "request failed",
+		"handler": "GetMenu",
+		"error":   "boom",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("Expected %s=%q, got %v", key, value, entry[key])
+		}
+	}
+	if _, ok := entry["time"]; !ok {
+		t.Error("Expected a time field")
+	}
+}
+
+func TestLogger_RespectsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	original := utils.Logger
+	utils.Logger = utils.NewLogger(&buf, utils.ParseLogLevel("error"), true)
+	defer func() { utils.Logger = original }()
+
+	utils.Info(context.Background(), "menu created")
+	utils.Warn(context.Background(), "validation failed")
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected info and warn to be dropped at error level, got %q", buf.String())
+	}
+}
+
+func TestInitLogger_RotatesBySize(t *testing.T) {
+	original := utils.Logger
+	defer func() { utils.Logger = original }()
+
+	dir := t.TempDir()
+	err := utils.InitLogger(utils.LogOptions{
+		Level:      "info",
+		JSON:       true,
+		Filename:   filepath.Join(dir, "app.log"),
+		MaxSizeMB:  1,
+		MaxBackups: 2,
+	})
+	if err != nil {
+		t.Fatalf("InitLogger failed: %v", err)
+	}
+
+	// ~1.2MB of log lines, past the 1MB threshold
+	payload := strings.Repeat("x", 1024)
+	for range 1200 {
+		utils.Info(context.Background(), "menu created", "payload", payload)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read log dir: %v", err)
+	}
+
+	var backups int
+	for _, entry := range entries {
+		if entry.Name() != "app.log" && strings.HasPrefix(entry.Name(), "app-") {
+			backups++
+		}
+	}
+	if backups != 1 {
+		t.Errorf("Expected one rotated backup next to app.log, got %d in %v", backups, entries)
+	}
+}
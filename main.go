@@ -1,9 +1,11 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	_ "github.com/andhikadk/stk-test-be/docs"
 
@@ -15,7 +17,6 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
 	fiberLogger "github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
@@ -38,9 +39,11 @@ import (
 // @schemes   http https
 
 func main() {
-	migrateCmd := flag.String("migrate", "", "Run migrations (use: -migrate or -migrate sql)")
-	seedCmd := flag.Bool("seed", false, "Seed database with sample data")
+	migrateCmd := flag.String("migrate", "", "Run migrations (use: -migrate sql, or -migrate plan to list pending ones)")
+	seedCmd := flag.String("seed", "", "Seed database with sample data (use: -seed run, -seed strict to abort on the first failing seed, which is always the case outside development, -seed rollback <file> to undo one seed, or -seed clear [table...] to forget every seed in development)")
 	statusCmd := flag.Bool("status", false, "Show migration status")
+	verifyCmd := flag.Bool("migrate-verify", false, "Verify applied migration checksums against migration files")
+	rollbackCmd := flag.Bool("rollback", false, "Roll back the last applied SQL migration")
 	flag.Parse()
 
 	cfg, err := config.LoadConfig()
@@ -48,7 +51,15 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	if err := utils.InitLogger(); err != nil {
+	if err := utils.InitLogger(utils.LogOptions{
+		Level:      cfg.LogLevel,
+		JSON:       !cfg.IsDevelopment(),
+		Console:    cfg.IsDevelopment(),
+		MaxSizeMB:  cfg.LogMaxSizeMB,
+		MaxBackups: cfg.LogMaxBackups,
+		MaxAgeDays: cfg.LogMaxAgeDays,
+		Compress:   cfg.LogCompress,
+	}); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
 
@@ -59,6 +70,10 @@ func main() {
 	defer database.Close()
 
 	if *migrateCmd != "" {
+		if *migrateCmd == "plan" {
+			showMigrationPlan(db)
+			return
+		}
 		if *migrateCmd == "sql" || *migrateCmd == "true" {
 			log.Println("Running SQL migrations from embedded files...")
 			if err := database.MigrateFromFS(db, MigrationsFS); err != nil {
@@ -68,12 +83,43 @@ func main() {
 		return
 	}
 
-	if *seedCmd {
-		log.Println("Seeding database...")
-		if err := database.SeedFromFS(db, MigrationsFS); err != nil {
-			log.Fatalf("Seeding failed: %v", err)
+	if *rollbackCmd {
+		log.Println("Rolling back last SQL migration...")
+		if err := database.RollbackFromFS(db, MigrationsFS); err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		return
+	}
+
+	if *seedCmd != "" {
+		switch *seedCmd {
+		case "run", "true", "strict":
+			log.Println("Seeding database...")
+			strict := *seedCmd == "strict" || !cfg.IsDevelopment()
+			if err := database.SeedFromFS(db, MigrationsFS, strict); err != nil {
+				log.Fatalf("Seeding failed: %v", err)
+			}
+			log.Println("Seeding completed successfully")
+		case "rollback":
+			name := flag.Arg(0)
+			if name == "" {
+				log.Fatal("Usage: -seed rollback <seed file>, e.g. -seed rollback 003_sample_menus.sql")
+			}
+			log.Printf("Rolling back seed %s...", name)
+			if err := database.RollbackSeedFromFS(db, MigrationsFS, name); err != nil {
+				log.Fatalf("Seed rollback failed: %v", err)
+			}
+		case "clear":
+			if !cfg.IsDevelopment() {
+				log.Fatal("-seed clear is only allowed in development")
+			}
+			log.Println("Clearing applied seeds...")
+			if err := database.ClearSeeds(db, flag.Args()...); err != nil {
+				log.Fatalf("Clearing seeds failed: %v", err)
+			}
+		default:
+			log.Fatalf("Unknown -seed mode %q (use run, strict, rollback or clear)", *seedCmd)
 		}
-		log.Println("Seeding completed successfully")
 		return
 	}
 
@@ -82,16 +128,38 @@ func main() {
 		return
 	}
 
+	if *verifyCmd {
+		if !verifyMigrations(db) {
+			database.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := database.Migrate(db, cfg); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database pool: %v", err)
+	}
+	if err := middleware.RegisterDBMetrics(sqlDB, cfg.DBName); err != nil {
+		log.Fatalf("Failed to register database metrics: %v", err)
+	}
+
 	app := fiber.New(fiber.Config{
 		AppName:           cfg.AppName,
 		ReadTimeout:       cfg.ReadTimeout,
 		WriteTimeout:      cfg.WriteTimeout,
 		IdleTimeout:       cfg.IdleTimeout,
+		BodyLimit:         cfg.BodyLimit,
+		Concurrency:       cfg.ServerConcurrency,
+		ProxyHeader:       cfg.TrustedProxyHeader,
 		EnablePrintRoutes: cfg.IsDevelopment(),
+		// Errors raised before routing, such as a body over BodyLimit, never
+		// reach ErrorHandlingMiddleware
+		ErrorHandler: middleware.ErrorHandler,
 	})
 
 	setupMiddleware(app, cfg)
@@ -136,18 +204,85 @@ func showMigrationStatus(db *gorm.DB) {
 	fmt.Println()
 }
 
+func showMigrationPlan(db *gorm.DB) {
+	fmt.Println("\n=== Migration Plan ===")
+
+	plan, err := database.PlanFromFS(db, MigrationsFS)
+	if err != nil {
+		log.Fatalf("Failed to plan migrations: %v", err)
+	}
+
+	if len(plan) == 0 {
+		fmt.Println("No pending migrations")
+	} else {
+		fmt.Println("Pending migrations (in order):")
+		for _, m := range plan {
+			fmt.Printf("  • %s\n", m)
+		}
+	}
+	fmt.Println()
+}
+
+func verifyMigrations(db *gorm.DB) bool {
+	fmt.Println("\n=== Migration Checksum Verification ===")
+
+	migrator := database.NewMigrator(db)
+	mismatches, err := migrator.VerifyChecksumsFromFS(MigrationsFS)
+	if errors.Is(err, database.ErrMigrationsNotInitialized) {
+		fmt.Println("Migrations not initialized: no migration has been applied yet")
+		fmt.Println()
+		return true
+	}
+	if err != nil {
+		log.Fatalf("Failed to verify migrations: %v", err)
+	}
+
+	if len(mismatches) == 0 {
+		fmt.Println("All applied migrations match their files")
+		fmt.Println()
+		return true
+	}
+
+	fmt.Println("Modified migrations:")
+	for _, mm := range mismatches {
+		if mm.Actual == "" {
+			fmt.Printf("  ✗ %s (file missing)\n", mm.Version)
+			continue
+		}
+		fmt.Printf("  ✗ %s (recorded %s, found %s)\n", mm.Version, shortChecksum(mm.Expected), shortChecksum(mm.Actual))
+	}
+	fmt.Println()
+	return false
+}
+
+// shortChecksum abbreviates a checksum for display; stored values may be
+// shorter than the abbreviation, e.g. empty on legacy rows
+func shortChecksum(sum string) string {
+	if len(sum) > 12 {
+		return sum[:12]
+	}
+	return sum
+}
+
 func setupMiddleware(app *fiber.App, cfg *config.Config) {
+	app.Use(middleware.RequestIDMiddleware())
+
 	app.Use(fiberLogger.New(fiberLogger.Config{
-		Format: "[${time}] ${status} - ${method} ${path} (${latency})\n",
+		Format: "[${time}] ${locals:requestid} ${status} - ${method} ${path} (${latency})\n",
 	}))
 
+	app.Use(middleware.ResponseTimeMiddleware())
+
+	app.Use(middleware.MetricsMiddleware())
+
 	app.Use(recover.New())
 
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: cfg.CORSAllowedOrigins,
-		AllowMethods: cfg.CORSAllowedMethods,
-		AllowHeaders: cfg.CORSAllowedHeaders,
-	}))
+	app.Use(middleware.CORSMiddleware(
+		cfg.CORSAllowedOrigins,
+		cfg.CORSAllowedMethods,
+		cfg.CORSAllowedHeaders,
+		cfg.CORSAllowCredentials,
+	))
 
 	app.Use(helmet.New())
 
@@ -155,6 +290,8 @@ func setupMiddleware(app *fiber.App, cfg *config.Config) {
 		Level: compress.LevelDefault,
 	}))
 
+	app.Use(middleware.TimeoutMiddleware(cfg.RequestTimeout, cfg.RequestTimeoutExempt...))
+
 	app.Use(middleware.ErrorHandlingMiddleware())
 }
 
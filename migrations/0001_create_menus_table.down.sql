@@ -0,0 +1,3 @@
+-- Revert 0001_create_menus_table.up.sql
+
+DROP TABLE IF EXISTS menus;
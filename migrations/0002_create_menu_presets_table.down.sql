@@ -0,0 +1,3 @@
+-- Revert 0002_create_menu_presets_table.up.sql
+
+DROP TABLE IF EXISTS menu_presets;
@@ -0,0 +1,16 @@
+-- Create menu_presets table
+-- Created at: 2026-10-16
+-- Purpose: Named snapshots of the menu tree that can be re-applied later
+
+CREATE TABLE IF NOT EXISTS menu_presets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    snapshot TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menu_presets_name ON menu_presets(name);
+
+COMMENT ON TABLE menu_presets IS 'Named snapshots of the menu tree';
+COMMENT ON COLUMN menu_presets.snapshot IS 'JSON-encoded menu tree without IDs';
@@ -0,0 +1,7 @@
+-- Add is_active flag to menus
+-- Created at: 2026-10-16
+-- Purpose: Hide menu items (and their subtrees) without deleting them
+
+ALTER TABLE menus ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+COMMENT ON COLUMN menus.is_active IS 'Inactive menus and their descendants are left out of active-only trees';
@@ -0,0 +1,3 @@
+-- Revert 0004_add_unique_menu_path_index.up.sql
+
+DROP INDEX IF EXISTS idx_menus_path_unique;
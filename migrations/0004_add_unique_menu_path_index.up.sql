@@ -0,0 +1,5 @@
+-- Enforce unique menu paths
+-- Created at: 2026-10-16
+-- Purpose: Two live menus with the same path break client-side routing
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menus_path_unique ON menus(path) WHERE path IS NOT NULL AND deleted_at IS NULL;
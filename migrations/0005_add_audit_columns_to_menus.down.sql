@@ -0,0 +1,4 @@
+-- Revert 0005_add_audit_columns_to_menus.up.sql
+
+ALTER TABLE menus DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE menus DROP COLUMN IF EXISTS created_by;
@@ -0,0 +1,9 @@
+-- Add created_by/updated_by audit columns to menus
+-- Created at: 2026-10-16
+-- Purpose: Record which authenticated user created or last changed a menu
+
+ALTER TABLE menus ADD COLUMN IF NOT EXISTS created_by BIGINT NULL;
+ALTER TABLE menus ADD COLUMN IF NOT EXISTS updated_by BIGINT NULL;
+
+COMMENT ON COLUMN menus.created_by IS 'User ID of the creator; NULL when created without authentication';
+COMMENT ON COLUMN menus.updated_by IS 'User ID of the last editor; NULL when never edited by an authenticated user';
@@ -0,0 +1,23 @@
+-- Create menus table with UUID primary key (MySQL)
+-- Created at: 2025-11-09
+-- Purpose: Hierarchical menu structure for navigation with UUID identifiers
+-- MySQL has no UUID type, so IDs are stored as their 36-character text form
+-- and generated by the application
+
+CREATE TABLE IF NOT EXISTS menus (
+    id CHAR(36) NOT NULL COMMENT 'Unique identifier (UUID)',
+    parent_id CHAR(36) NULL COMMENT 'Reference to parent menu item (NULL for root menus)',
+    title VARCHAR(255) NOT NULL COMMENT 'Menu item title displayed in UI',
+    path VARCHAR(255) NULL COMMENT 'URL path for navigation (NULL for parent menus)',
+    icon VARCHAR(100) NULL COMMENT 'Icon identifier for UI display',
+    order_index INT NOT NULL DEFAULT 0 COMMENT 'Order position within same parent level',
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    deleted_at DATETIME(3) NULL COMMENT 'Soft delete timestamp (NULL if not deleted)',
+    PRIMARY KEY (id),
+    INDEX idx_menus_parent_id (parent_id),
+    INDEX idx_menus_order_index (order_index),
+    INDEX idx_menus_deleted_at (deleted_at),
+    INDEX idx_menus_parent_order (parent_id, order_index),
+    CONSTRAINT fk_menus_parent FOREIGN KEY (parent_id) REFERENCES menus(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Hierarchical menu structure for navigation';
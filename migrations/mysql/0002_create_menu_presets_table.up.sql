@@ -0,0 +1,13 @@
+-- Create menu_presets table (MySQL)
+-- Created at: 2026-10-16
+-- Purpose: Named snapshots of the menu tree that can be re-applied later
+
+CREATE TABLE IF NOT EXISTS menu_presets (
+    id CHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    snapshot MEDIUMTEXT NOT NULL COMMENT 'JSON-encoded menu tree without IDs',
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_menu_presets_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Named snapshots of the menu tree';
@@ -0,0 +1,3 @@
+-- Revert 0003_add_is_active_to_menus.up.sql
+
+ALTER TABLE menus DROP COLUMN is_active;
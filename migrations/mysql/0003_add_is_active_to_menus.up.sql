@@ -0,0 +1,7 @@
+-- Add is_active flag to menus (MySQL)
+-- Created at: 2026-10-16
+-- Purpose: Hide menu items (and their subtrees) without deleting them
+
+ALTER TABLE menus
+    ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE
+    COMMENT 'Inactive menus and their descendants are left out of active-only trees';
@@ -0,0 +1,5 @@
+-- Revert 0004_add_unique_menu_path_index.up.sql
+
+ALTER TABLE menus
+    DROP INDEX idx_menus_path_unique,
+    DROP COLUMN path_live;
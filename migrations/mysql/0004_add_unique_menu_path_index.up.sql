@@ -0,0 +1,12 @@
+-- Enforce unique menu paths (MySQL)
+-- Created at: 2026-10-16
+-- Purpose: Two live menus with the same path break client-side routing
+-- MySQL has no partial indexes, so the index covers a generated column that
+-- holds the path of live menus and NULL otherwise; a unique index allows any
+-- number of NULLs, which leaves parents and soft-deleted menus unconstrained
+
+ALTER TABLE menus
+    ADD COLUMN path_live VARCHAR(255)
+        GENERATED ALWAYS AS (IF(deleted_at IS NULL, path, NULL)) STORED
+        COMMENT 'path while the menu is not soft-deleted; backs idx_menus_path_unique',
+    ADD UNIQUE INDEX idx_menus_path_unique (path_live);
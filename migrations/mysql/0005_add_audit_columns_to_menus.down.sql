@@ -0,0 +1,5 @@
+-- Revert 0005_add_audit_columns_to_menus.up.sql
+
+ALTER TABLE menus
+    DROP COLUMN updated_by,
+    DROP COLUMN created_by;
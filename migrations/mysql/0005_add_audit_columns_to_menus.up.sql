@@ -0,0 +1,9 @@
+-- Add created_by/updated_by audit columns to menus (MySQL)
+-- Created at: 2026-10-16
+-- Purpose: Record which authenticated user created or last changed a menu
+
+ALTER TABLE menus
+    ADD COLUMN created_by BIGINT NULL
+        COMMENT 'User ID of the creator; NULL when created without authentication',
+    ADD COLUMN updated_by BIGINT NULL
+        COMMENT 'User ID of the last editor; NULL when never edited by an authenticated user';
@@ -0,0 +1,25 @@
+-- Remove the sample navigation tree seeded by 003_sample_menus.sql
+-- Children first, for databases without ON DELETE CASCADE on parent_id
+
+DELETE FROM menus WHERE id IN (
+    '889e65cc-bb7c-4395-a1fb-107e1bcf9e6c',
+    'dcb2b39e-6e1a-4838-a976-8fcfb2347f04'
+);
+
+DELETE FROM menus WHERE id IN (
+    '836bef2c-59fc-4331-b2f1-14559936328d',
+    '20a088f4-3dde-4e04-8bdf-15112bb8ded5',
+    'f36b6b72-cfe2-41af-a38d-283440361b8a',
+    '5fb49fd3-e097-42b5-b047-e3ab2e05b0e1',
+    'c187067c-725a-4cbb-8e47-33fb9291ec8f',
+    'b5051190-cddd-4fd5-b3c2-d2823e72f15f',
+    '47477272-cd70-4c3d-b7e2-e6f7e2e87828',
+    '470514ea-e541-4a8d-96f7-c82024b16796'
+);
+
+DELETE FROM menus WHERE id IN (
+    '1372235c-c8ef-4341-b860-889578498757',
+    '1332276b-b147-400d-a328-1f41cbb675d0',
+    'd7bf4a61-45b9-4869-9c27-69839b78990f',
+    '2c2d870c-0760-4098-a409-bdaf87bdbd44'
+);
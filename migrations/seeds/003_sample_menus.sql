@@ -0,0 +1,78 @@
+-- Seed a sample navigation tree
+-- Created at: 2026-10-16
+-- Three levels: root sections, their pages, and the pages under Access Control
+-- Rows whose id already exists are skipped with INSERT ... SELECT ... WHERE
+-- NOT EXISTS, which PostgreSQL, MySQL and SQLite all accept
+
+-- Root sections
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT '1372235c-c8ef-4341-b860-889578498757', NULL, 'Dashboard', '/dashboard', 'icon-dashboard', 0, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = '1372235c-c8ef-4341-b860-889578498757');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT '1332276b-b147-400d-a328-1f41cbb675d0', NULL, 'Content', NULL, 'icon-content', 1, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = '1332276b-b147-400d-a328-1f41cbb675d0');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT 'd7bf4a61-45b9-4869-9c27-69839b78990f', NULL, 'Users', NULL, 'icon-users', 2, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = 'd7bf4a61-45b9-4869-9c27-69839b78990f');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT '2c2d870c-0760-4098-a409-bdaf87bdbd44', NULL, 'Settings', NULL, 'icon-settings', 3, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = '2c2d870c-0760-4098-a409-bdaf87bdbd44');
+
+-- Pages under each section
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT '836bef2c-59fc-4331-b2f1-14559936328d', '1332276b-b147-400d-a328-1f41cbb675d0', 'Pages', '/content/pages', 'icon-file', 0, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = '836bef2c-59fc-4331-b2f1-14559936328d');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT '20a088f4-3dde-4e04-8bdf-15112bb8ded5', '1332276b-b147-400d-a328-1f41cbb675d0', 'Posts', '/content/posts', 'icon-edit', 1, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = '20a088f4-3dde-4e04-8bdf-15112bb8ded5');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT 'f36b6b72-cfe2-41af-a38d-283440361b8a', '1332276b-b147-400d-a328-1f41cbb675d0', 'Media Library', '/content/media', 'icon-image', 2, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = 'f36b6b72-cfe2-41af-a38d-283440361b8a');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT '5fb49fd3-e097-42b5-b047-e3ab2e05b0e1', 'd7bf4a61-45b9-4869-9c27-69839b78990f', 'All Users', '/users', 'icon-list', 0, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = '5fb49fd3-e097-42b5-b047-e3ab2e05b0e1');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT 'c187067c-725a-4cbb-8e47-33fb9291ec8f', 'd7bf4a61-45b9-4869-9c27-69839b78990f', 'Access Control', NULL, 'icon-lock', 1, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = 'c187067c-725a-4cbb-8e47-33fb9291ec8f');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT 'b5051190-cddd-4fd5-b3c2-d2823e72f15f', '2c2d870c-0760-4098-a409-bdaf87bdbd44', 'General', '/settings/general', 'icon-sliders', 0, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = 'b5051190-cddd-4fd5-b3c2-d2823e72f15f');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT '47477272-cd70-4c3d-b7e2-e6f7e2e87828', '2c2d870c-0760-4098-a409-bdaf87bdbd44', 'Security', '/settings/security', 'icon-shield', 1, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = '47477272-cd70-4c3d-b7e2-e6f7e2e87828');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT '470514ea-e541-4a8d-96f7-c82024b16796', '2c2d870c-0760-4098-a409-bdaf87bdbd44', 'Integrations', '/settings/integrations', 'icon-plug', 2, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = '470514ea-e541-4a8d-96f7-c82024b16796');
+
+-- Pages under Users > Access Control
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT '889e65cc-bb7c-4395-a1fb-107e1bcf9e6c', 'c187067c-725a-4cbb-8e47-33fb9291ec8f', 'Roles', '/users/roles', 'icon-badge', 0, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = '889e65cc-bb7c-4395-a1fb-107e1bcf9e6c');
+
+INSERT INTO menus (id, parent_id, title, path, icon, order_index, is_active, created_at, updated_at)
+SELECT 'dcb2b39e-6e1a-4838-a976-8fcfb2347f04', 'c187067c-725a-4cbb-8e47-33fb9291ec8f', 'Permissions', '/users/permissions', 'icon-key', 1, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (SELECT 1) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM menus WHERE id = 'dcb2b39e-6e1a-4838-a976-8fcfb2347f04');
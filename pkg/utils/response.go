@@ -1,6 +1,11 @@
 package utils
 
 import (
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+
 	"github.com/andhikadk/stk-test-be/internal/models"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,11 +21,13 @@ func SuccessResponse(c *fiber.Ctx, statusCode int, message string, data interfac
 	return c.Status(statusCode).JSON(response)
 }
 
-// ErrorResponse sends an error response
+// ErrorResponse sends an error response with the generic error code for
+// statusCode
 func ErrorResponse(c *fiber.Ctx, statusCode int, message string) error {
 	response := models.APIResponse{
 		Status:  statusCode,
 		Message: message,
+		Code:    models.CodeForStatus(statusCode),
 		Error:   message,
 	}
 	return c.Status(statusCode).JSON(response)
@@ -28,22 +35,68 @@ func ErrorResponse(c *fiber.Ctx, statusCode int, message string) error {
 
 // PaginatedResponse sends a paginated response
 func PaginatedResponse(c *fiber.Ctx, message string, data interface{}, page, limit int, total int64) error {
+	totalPages := totalPages(limit, total)
 	response := models.PaginatedResponse{
-		Status:  fiber.StatusOK,
-		Message: message,
-		Data:    data,
-		Page:    page,
-		Limit:   limit,
-		Total:   total,
+		Status:     fiber.StatusOK,
+		Message:    message,
+		Data:       data,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+		Links:      paginationLinks(c, page, max(totalPages, 1)),
 	}
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
+// totalPages returns ceil(total/limit), which is 0 for an empty result
+func totalPages(limit int, total int64) int {
+	if limit <= 0 || total <= 0 {
+		return 0
+	}
+	return int((total + int64(limit) - 1) / int64(limit))
+}
+
+// paginationLinks builds first/prev/next/last URLs from the current request,
+// omitting prev and next at the boundaries
+func paginationLinks(c *fiber.Ctx, page, lastPage int) *models.PaginationLinks {
+	query, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		query = url.Values{}
+	}
+
+	pageURL := func(p int) string {
+		query.Set("page", strconv.Itoa(p))
+		return c.Path() + "?" + query.Encode()
+	}
+
+	links := &models.PaginationLinks{
+		First: pageURL(1),
+		Last:  pageURL(lastPage),
+	}
+	if page > 1 {
+		links.Prev = pageURL(min(page-1, lastPage))
+	}
+	if page < lastPage {
+		links.Next = pageURL(page + 1)
+	}
+	return links
+}
+
 // CreatedResponse sends a 201 created response
 func CreatedResponse(c *fiber.Ctx, message string, data interface{}) error {
 	return SuccessResponse(c, fiber.StatusCreated, message, data)
 }
 
+// CreatedResponseWithLocation sends a 201 created response with a Location
+// header pointing at the new resource
+func CreatedResponseWithLocation(c *fiber.Ctx, location, message string, data interface{}) error {
+	c.Location(location)
+	return CreatedResponse(c, message, data)
+}
+
 // BadRequestResponse sends a 400 bad request response
 func BadRequestResponse(c *fiber.Ctx, message string) error {
 	return ErrorResponse(c, fiber.StatusBadRequest, message)
@@ -69,6 +122,24 @@ func ConflictResponse(c *fiber.Ctx, message string) error {
 	return ErrorResponse(c, fiber.StatusConflict, message)
 }
 
+// TooManyRequestsResponse sends a 429 too many requests response with a
+// Retry-After header and the same delay in the body, rounded up to whole seconds
+func TooManyRequestsResponse(c *fiber.Ctx, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+	return c.Status(fiber.StatusTooManyRequests).JSON(models.APIResponse{
+		Status:  fiber.StatusTooManyRequests,
+		Message: "Too many requests",
+		Code:    models.CodeRateLimited,
+		Data:    models.RetryAfterData{RetryAfterSeconds: seconds},
+		Error:   "rate limit exceeded, retry after " + strconv.Itoa(seconds) + " seconds",
+	})
+}
+
 // InternalErrorResponse sends a 500 internal server error response
 func InternalErrorResponse(c *fiber.Ctx, message string) error {
 	return ErrorResponse(c, fiber.StatusInternalServerError, message)
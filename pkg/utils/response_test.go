@@ -0,0 +1,229 @@
+package utils_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andhikadk/stk-test-be/internal/models"
+	"github.com/andhikadk/stk-test-be/internal/testutil"
+	"github.com/andhikadk/stk-test-be/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func paginatedApp(total int64) *fiber.App {
+	app := fiber.New()
+	app.Get("/items", func(c *fiber.Ctx) error {
+		return utils.PaginatedResponse(c, "Items retrieved", []string{}, c.QueryInt("page", 1), c.QueryInt("limit", 10), total)
+	})
+	return app
+}
+
+func TestPaginatedResponse_Links(t *testing.T) {
+	tests := []struct {
+		name string
+		page int
+		prev string
+		next string
+	}{
+		{
+			name: "first page",
+			page: 1,
+			prev: "",
+			next: "/items?limit=10&page=2&q=go",
+		},
+		{
+			name: "middle page",
+			page: 2,
+			prev: "/items?limit=10&page=1&q=go",
+			next: "/items?limit=10&page=3&q=go",
+		},
+		{
+			name: "last page",
+			page: 3,
+			prev: "/items?limit=10&page=2&q=go",
+			next: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := paginatedApp(25)
+
+			url := fmt.Sprintf("/items?q=go&limit=10&page=%d", tt.page)
+			resp, err := app.Test(httptest.NewRequest("GET", url, nil))
+
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			testutil.AssertStatusCode(t, fiber.StatusOK, resp)
+
+			var result models.PaginatedResponse
+			testutil.ParseJSONResponse(t, resp.Body, &result)
+
+			if result.Links == nil {
+				t.Fatalf("Expected pagination links, got nil")
+			}
+			testutil.AssertEqual(t, "/items?limit=10&page=1&q=go", result.Links.First)
+			testutil.AssertEqual(t, "/items?limit=10&page=3&q=go", result.Links.Last)
+			testutil.AssertEqual(t, tt.prev, result.Links.Prev)
+			testutil.AssertEqual(t, tt.next, result.Links.Next)
+		})
+	}
+}
+
+func TestPaginatedResponse_LinksEmptyResult(t *testing.T) {
+	app := paginatedApp(0)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/items", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	var result models.PaginatedResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, "/items?page=1", result.Links.First)
+	testutil.AssertEqual(t, "/items?page=1", result.Links.Last)
+	testutil.AssertEmpty(t, result.Links.Prev)
+	testutil.AssertEmpty(t, result.Links.Next)
+}
+
+func TestErrorResponse_Code(t *testing.T) {
+	tests := []struct {
+		name     string
+		respond  func(c *fiber.Ctx) error
+		status   int
+		expected string
+	}{
+		{name: "bad request", respond: func(c *fiber.Ctx) error { return utils.BadRequestResponse(c, "bad") }, status: fiber.StatusBadRequest, expected: models.CodeBadRequest},
+		{name: "not found", respond: func(c *fiber.Ctx) error { return utils.NotFoundResponse(c, "missing") }, status: fiber.StatusNotFound, expected: models.CodeNotFound},
+		{name: "conflict", respond: func(c *fiber.Ctx) error { return utils.ConflictResponse(c, "taken") }, status: fiber.StatusConflict, expected: models.CodeConflict},
+		{name: "internal error", respond: func(c *fiber.Ctx) error { return utils.InternalErrorResponse(c, "boom") }, status: fiber.StatusInternalServerError, expected: models.CodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", tt.respond)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			testutil.AssertStatusCode(t, tt.status, resp)
+
+			var result models.APIResponse
+			testutil.ParseJSONResponse(t, resp.Body, &result)
+
+			testutil.AssertEqual(t, tt.expected, result.Code)
+		})
+	}
+}
+
+func TestTooManyRequestsResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter time.Duration
+		expected   int
+	}{
+		{name: "whole seconds", retryAfter: 30 * time.Second, expected: 30},
+		{name: "rounds up", retryAfter: 1500 * time.Millisecond, expected: 2},
+		{name: "never below one second", retryAfter: 0, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/limited", func(c *fiber.Ctx) error {
+				return utils.TooManyRequestsResponse(c, tt.retryAfter)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/limited", nil))
+
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			testutil.AssertStatusCode(t, fiber.StatusTooManyRequests, resp)
+			testutil.AssertEqual(t, strconv.Itoa(tt.expected), resp.Header.Get(fiber.HeaderRetryAfter))
+
+			var result struct {
+				Status int                   `json:"status"`
+				Code   string                `json:"code"`
+				Data   models.RetryAfterData `json:"data"`
+			}
+			testutil.ParseJSONResponse(t, resp.Body, &result)
+
+			testutil.AssertEqual(t, fiber.StatusTooManyRequests, result.Status)
+			testutil.AssertEqual(t, models.CodeRateLimited, result.Code)
+			testutil.AssertEqual(t, tt.expected, result.Data.RetryAfterSeconds)
+		})
+	}
+}
+
+func TestPaginatedResponse_NavigationMetadata(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int64
+		page       int
+		totalPages int
+		hasNext    bool
+		hasPrev    bool
+	}{
+		{name: "first of three", total: 25, page: 1, totalPages: 3, hasNext: true, hasPrev: false},
+		{name: "middle", total: 25, page: 2, totalPages: 3, hasNext: true, hasPrev: true},
+		{name: "last", total: 25, page: 3, totalPages: 3, hasNext: false, hasPrev: true},
+		{name: "exact multiple", total: 30, page: 3, totalPages: 3, hasNext: false, hasPrev: true},
+		{name: "empty", total: 0, page: 1, totalPages: 0, hasNext: false, hasPrev: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := paginatedApp(tt.total)
+
+			url := fmt.Sprintf("/items?limit=10&page=%d", tt.page)
+			resp, err := app.Test(httptest.NewRequest("GET", url, nil))
+
+			if err != nil {
+				t.Fatalf("Failed to perform request: %v", err)
+			}
+
+			var result models.PaginatedResponse
+			testutil.ParseJSONResponse(t, resp.Body, &result)
+
+			testutil.AssertEqual(t, tt.totalPages, result.TotalPages)
+			testutil.AssertEqual(t, tt.hasNext, result.HasNext)
+			testutil.AssertEqual(t, tt.hasPrev, result.HasPrev)
+		})
+	}
+}
+
+func TestCreatedResponseWithLocation(t *testing.T) {
+	app := fiber.New()
+	app.Post("/items", func(c *fiber.Ctx) error {
+		return utils.CreatedResponseWithLocation(c, "/items/42", "Item created", fiber.Map{"id": 42})
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/items", nil))
+
+	if err != nil {
+		t.Fatalf("Failed to perform request: %v", err)
+	}
+
+	testutil.AssertStatusCode(t, fiber.StatusCreated, resp)
+	testutil.AssertEqual(t, "/items/42", resp.Header.Get(fiber.HeaderLocation))
+
+	var result models.APIResponse
+	testutil.ParseJSONResponse(t, resp.Body, &result)
+
+	testutil.AssertEqual(t, fiber.StatusCreated, result.Status)
+	testutil.AssertEqual(t, "Item created", result.Message)
+}